@@ -8,15 +8,11 @@ import (
 )
 
 // NewIntegrationClient returns a preconfigured integration client
-// Requires AUTHORIZATIONHEADERKEY environment variable to be defined
+// Requires the AUTHORIZATIONHEADERKEY environment variable or a token in the
+// selected credentials file profile
 // Optional APPLICATIONID environment variable to identify your application in logs
 // To be used with integration tests only
 func NewIntegrationClient() (*striketracker.Client, error) {
-	authorizationHeaderToken := os.Getenv("AUTHORIZATIONHEADERKEY")
-	if authorizationHeaderToken == "" {
-		return nil, fmt.Errorf("No AUTHORIZATIONHEADERKEY defined, cannot run integration tests")
-	}
-
 	appID := os.Getenv("APPLICATIONID")
 	if appID == "" {
 		appID = "WurlWindIntegration"
@@ -26,10 +22,10 @@ func NewIntegrationClient() (*striketracker.Client, error) {
 	c, err := striketracker.NewClientWithOptions(
 		striketracker.WithApplicationID(appID),
 		striketracker.WithDebug(true),
-		striketracker.WithAuthorizationHeaderToken(authorizationHeaderToken),
+		striketracker.WithCredentialProviders(striketracker.DefaultCredentialChain()...),
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cannot run integration tests: %w", err)
 	}
 
 	return c, nil
@@ -2,6 +2,8 @@
 package integration
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
 
@@ -19,13 +21,40 @@ func GetIntegrationAccountHash() (string, error) {
 	return accountHash, nil
 }
 
+// Integration certificate environment variables, either holding the PEM or naming a file
+const (
+	envCert     = "INTEGRATIONCERT"
+	envCertFile = "INTEGRATIONCERTFILE"
+)
+
+// GetIntegrationCertificateCommonName returns the common name of the
+// integration certificate, which the certificates suite uploads
+func GetIntegrationCertificateCommonName() (string, error) {
+	cert := &models.Certificate{}
+	if certFile := os.Getenv(envCertFile); certFile != "" {
+		if err := cert.CertificateFromFile(certFile); err != nil {
+			return "", err
+		}
+	} else if err := cert.CertificateFromEnv(envCert); err != nil {
+		return "", err
+	}
+
+	block, _ := pem.Decode([]byte(cert.Certificate))
+	if block == nil {
+		return "", fmt.Errorf("integration certificate is not PEM encoded")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("parsing integration certificate: %w", err)
+	}
+	return parsed.Subject.CommonName, nil
+}
+
 // GetCertificateIntegrationValues fetches the integration certificate from Env var
 func GetCertificateIntegrationValues() (*models.Certificate, error) {
 	var (
 		envPrivKey     = "INTEGRATIONPRIVATEKEY"
 		envPrivKeyFile = "INTEGRATIONPRIVATEKEYFILE"
-		envCert        = "INTEGRATIONCERT"
-		envCertFile    = "INTEGRATIONCERTFILE"
 		envBundle      = "INTEGRATIONBUNDLE"
 		envBundleFile  = "INTEGRATIONBUNDLEFILE"
 	)
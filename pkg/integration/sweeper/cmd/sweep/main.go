@@ -0,0 +1,53 @@
+// Command sweep deletes origins, certificates and hosts leaked by failed
+// integration runs in the INTEGRATIONACCOUNTHASH account
+//
+//  INTEGRATIONACCOUNTHASH=f98fsj32k SWEEPMINAGE=1h go run ./pkg/integration/sweeper/cmd/sweep -dry-run
+//
+// Origins and hosts are matched by SWEEPPREFIX, a comma separated list of
+// name prefixes defaulting to "CUGD Integration Test", certificates by
+// SWEEPCERTSUFFIX, common names whose subdomains also match, defaulting to
+// the common name of INTEGRATIONCERT or INTEGRATIONCERTFILE, and all by
+// SWEEPMINAGE and SWEEPSINCE, an RFC 3339 creation cutoff
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/openwurl/wurlwind/pkg/integration/sweeper"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "list matching resources without deleting them")
+	flag.Parse()
+
+	s, err := sweeper.NewFromEnv()
+	if err != nil {
+		log.Fatalf("cannot sweep: %v", err)
+	}
+	s.DryRun = *dryRun
+
+	swept, err := s.Sweep(context.Background())
+
+	failed := 0
+	for _, resource := range swept {
+		switch {
+		case resource.Err != nil:
+			failed++
+			log.Printf("failed to delete %s %s %q: %v", resource.Kind, resource.ID, resource.Name, resource.Err)
+		case s.DryRun:
+			log.Printf("would delete %s %s %q created %s", resource.Kind, resource.ID, resource.Name, resource.Created)
+		default:
+			log.Printf("deleted %s %s %q created %s", resource.Kind, resource.ID, resource.Name, resource.Created)
+		}
+	}
+
+	if err != nil {
+		log.Fatalf("sweep incomplete: %v", err)
+	}
+	if failed > 0 {
+		log.Fatalf("%d of %d resources could not be deleted", failed, len(swept))
+	}
+	log.Printf("swept %d resources matching %v and certificates matching %v", len(swept), s.Prefixes, s.CertificateSuffixes)
+}
@@ -0,0 +1,264 @@
+// Package sweeper deletes resources leaked by failed integration runs
+package sweeper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openwurl/wurlwind/pkg/integration"
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/services"
+	"github.com/openwurl/wurlwind/striketracker/services/certificates"
+	"github.com/openwurl/wurlwind/striketracker/services/hosts"
+	"github.com/openwurl/wurlwind/striketracker/services/origin"
+)
+
+// DefaultSweepPrefix matches the resources created by the integration suites
+const DefaultSweepPrefix = "CUGD Integration Test"
+
+// Sweeper environment variables
+const (
+	// SweepPrefixEnv is a comma separated list of name prefixes to sweep
+	SweepPrefixEnv = "SWEEPPREFIX"
+	// SweepMinAgeEnv is the minimum age of swept resources, such as 1h
+	SweepMinAgeEnv = "SWEEPMINAGE"
+	// SweepCertSuffixEnv is a comma separated list of certificate common
+	// name suffixes to sweep, defaulting to the integration certificate's
+	SweepCertSuffixEnv = "SWEEPCERTSUFFIX"
+	// SweepSinceEnv limits the sweep to resources created at or after an
+	// RFC 3339 time, such as the start of an integration run
+	SweepSinceEnv = "SWEEPSINCE"
+)
+
+// createdLayouts are the date formats Striketracker has been seen to return
+var createdLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// SweptResource is a resource matched by the sweeper
+type SweptResource struct {
+	Kind    string // origin, certificate or host
+	ID      string
+	Name    string
+	Created string
+	Err     error
+}
+
+// Sweeper deletes resources leaked by failed integration runs
+//
+// Origins and hosts are matched by name prefix. Certificates are matched
+// by common name suffix on a label boundary, as a common name is a hostname which can never
+// carry the name prefix, and are left alone when no suffix is given.
+// Hosts are swept first as they may reference origins and certificates.
+type Sweeper struct {
+	// Prefixes a resource name must start with to be swept
+	Prefixes []string
+	// CertificateSuffixes a certificate common name must equal or be a
+	// subdomain of to be swept, such as the integration certificate's
+	// common name
+	CertificateSuffixes []string
+	// MinAge skips resources created more recently, such as those of a
+	// suite running concurrently, resources with unknown age are kept
+	// unless MinAge is zero
+	MinAge time.Duration
+	// Since skips resources created before it when set, so the resources
+	// leaked by a run can be swept as soon as it finishes
+	Since time.Time
+	// DryRun reports the matching resources without deleting them
+	DryRun bool
+
+	accountHash  string
+	origins      services.Origins
+	certificates services.Certificates
+	hosts        services.Hosts
+	now          func() time.Time
+}
+
+// New returns a sweeper for the account matching DefaultSweepPrefix
+func New(c *striketracker.Client, accountHash string) *Sweeper {
+	return NewWithServices(accountHash, origin.New(c), certificates.New(c), hosts.New(c))
+}
+
+// NewWithServices returns a sweeper for the account using the given
+// services, such as mocks, matching DefaultSweepPrefix
+func NewWithServices(accountHash string, origins services.Origins, certificates services.Certificates, hosts services.Hosts) *Sweeper {
+	return &Sweeper{
+		Prefixes:     []string{DefaultSweepPrefix},
+		accountHash:  accountHash,
+		origins:      origins,
+		certificates: certificates,
+		hosts:        hosts,
+		now:          time.Now,
+	}
+}
+
+// NewFromEnv returns a sweeper for the integration client and account,
+// reading prefixes from SWEEPPREFIX, certificate suffixes from
+// SWEEPCERTSUFFIX or the integration certificate, the minimum age from
+// SWEEPMINAGE and the creation cutoff from SWEEPSINCE
+func NewFromEnv() (*Sweeper, error) {
+	c, err := integration.NewIntegrationClient()
+	if err != nil {
+		return nil, err
+	}
+
+	accountHash, err := integration.GetIntegrationAccountHash()
+	if err != nil {
+		return nil, err
+	}
+
+	s := New(c, accountHash)
+	if err = s.configureFromEnv(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// configureFromEnv applies the sweeper environment variables
+func (s *Sweeper) configureFromEnv() error {
+	if prefixes := splitList(os.Getenv(SweepPrefixEnv)); len(prefixes) > 0 {
+		s.Prefixes = prefixes
+	}
+
+	s.CertificateSuffixes = splitList(os.Getenv(SweepCertSuffixEnv))
+	if len(s.CertificateSuffixes) == 0 {
+		if commonName, err := integration.GetIntegrationCertificateCommonName(); err == nil && commonName != "" {
+			s.CertificateSuffixes = []string{commonName}
+		}
+	}
+
+	if minAge := os.Getenv(SweepMinAgeEnv); minAge != "" {
+		var err error
+		if s.MinAge, err = time.ParseDuration(minAge); err != nil {
+			return fmt.Errorf("%s must be a duration such as 1h: %w", SweepMinAgeEnv, err)
+		}
+	}
+
+	if since := os.Getenv(SweepSinceEnv); since != "" {
+		var err error
+		if s.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return fmt.Errorf("%s must be an RFC 3339 time such as 2020-06-01T12:00:00Z: %w", SweepSinceEnv, err)
+		}
+	}
+
+	return nil
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(list string) []string {
+	var out []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Sweep lists hosts, origins and certificates and deletes those matching
+//
+// Every matching resource is attempted, the returned list includes the
+// error of any which could not be deleted. An error is returned when a
+// listing fails.
+func (s *Sweeper) Sweep(ctx context.Context) ([]SweptResource, error) {
+	var swept []SweptResource
+
+	hostList, err := s.hosts.List(ctx, s.accountHash)
+	if err != nil {
+		return swept, fmt.Errorf("listing hosts: %w", err)
+	}
+	for _, host := range hostList.List {
+		if s.matches(hasPrefix(host.Name, s.Prefixes), host.CreatedDate) {
+			resource := SweptResource{Kind: "host", ID: host.HashCode, Name: host.Name, Created: host.CreatedDate}
+			if !s.DryRun {
+				resource.Err = s.hosts.Delete(ctx, s.accountHash, host.HashCode)
+			}
+			swept = append(swept, resource)
+		}
+	}
+
+	originList, err := s.origins.List(ctx, s.accountHash)
+	if err != nil {
+		return swept, fmt.Errorf("listing origins: %w", err)
+	}
+	for _, o := range originList.List {
+		if s.matches(hasPrefix(o.Name, s.Prefixes), o.CreatedDate) {
+			resource := SweptResource{Kind: "origin", ID: fmt.Sprint(o.ID), Name: o.Name, Created: o.CreatedDate}
+			if !s.DryRun {
+				resource.Err = s.origins.Delete(ctx, s.accountHash, o.ID)
+			}
+			swept = append(swept, resource)
+		}
+	}
+
+	if len(s.CertificateSuffixes) == 0 {
+		return swept, nil
+	}
+
+	certificateList, err := s.certificates.List(ctx, s.accountHash)
+	if err != nil {
+		return swept, fmt.Errorf("listing certificates: %w", err)
+	}
+	for _, cert := range certificateList.List {
+		if s.matches(hasSuffix(cert.CommonName, s.CertificateSuffixes), cert.CreatedDate) {
+			resource := SweptResource{Kind: "certificate", ID: fmt.Sprint(cert.ID), Name: cert.CommonName, Created: cert.CreatedDate}
+			if !s.DryRun {
+				resource.Err = s.certificates.Delete(ctx, s.accountHash, cert.ID)
+			}
+			swept = append(swept, resource)
+		}
+	}
+
+	return swept, nil
+}
+
+// matches reports whether a named resource was created within the
+// window swept, at least MinAge ago and not before Since
+func (s *Sweeper) matches(named bool, created string) bool {
+	if !named {
+		return false
+	}
+
+	if s.MinAge == 0 && s.Since.IsZero() {
+		return true
+	}
+
+	for _, layout := range createdLayouts {
+		if t, err := time.Parse(layout, created); err == nil {
+			return s.now().Sub(t) >= s.MinAge && !t.Before(s.Since)
+		}
+	}
+	return false
+}
+
+// hasPrefix reports whether name starts with any of the prefixes
+func hasPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSuffix reports whether the hostname name is any of the suffixes or
+// a subdomain of one, so integration.example.com matches
+// www.integration.example.com but never foo-integration.example.com
+func hasSuffix(name string, suffixes []string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range suffixes {
+		suffix = strings.ToLower(strings.TrimPrefix(suffix, "."))
+		if suffix == "" {
+			continue
+		}
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package sweeper
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services/mocks"
+	"github.com/openwurl/wurlwind/striketracker/striketrackertest"
+)
+
+func TestSweeper(t *testing.T) {
+	s := striketrackertest.NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-2 * time.Hour).Format(time.RFC3339)
+	recent := now.Add(-time.Minute).Format(time.RFC3339)
+	account := striketrackertest.AccountHash
+
+	s.AddOrigin(account, models.Origin{Name: DefaultSweepPrefix + " 01", CreatedDate: old})
+	s.AddOrigin(account, models.Origin{Name: DefaultSweepPrefix + " 02", CreatedDate: recent})
+	s.AddOrigin(account, models.Origin{Name: "Production origin", CreatedDate: old})
+	s.AddHost(account, models.Host{Name: DefaultSweepPrefix + " host", CreatedDate: "2020-06-01 09:00:00"})
+	s.AddCertificate(account, models.Certificate{CommonName: "integration.example.com", CreatedDate: old})
+	s.AddCertificate(account, models.Certificate{CommonName: "www.example.com", CreatedDate: old})
+	s.AddCertificate(account, models.Certificate{CommonName: "foo-integration.example.com", CreatedDate: old})
+
+	// The default configuration sweeps certificates sharing the integration certificate's common name
+	certificate, _, err := striketrackertest.GenerateCertificate("integration.example.com")
+	if err != nil {
+		t.Fatalf("Expected a generated certificate but got: %v", err)
+	}
+	for name, value := range map[string]string{"INTEGRATIONCERT": certificate, SweepMinAgeEnv: "1h", SweepPrefixEnv: "", SweepCertSuffixEnv: ""} {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+
+	sweeper := New(c, account)
+	if err = sweeper.configureFromEnv(); err != nil {
+		t.Fatalf("Expected the default configuration to load but got: %v", err)
+	}
+	if len(sweeper.Prefixes) != 1 || sweeper.Prefixes[0] != DefaultSweepPrefix || sweeper.MinAge != time.Hour {
+		t.Fatalf("Expected the default prefix and a minimum age of 1h but got %v and %v", sweeper.Prefixes, sweeper.MinAge)
+	}
+	sweeper.now = func() time.Time { return now }
+
+	sweeper.DryRun = true
+	swept, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Expected dry run to succeed but got: %v", err)
+	}
+	if len(swept) != 3 || len(s.Origins(account)) != 3 {
+		t.Fatalf("Expected 3 matches and nothing deleted in a dry run but got %+v", swept)
+	}
+
+	sweeper.DryRun = false
+	swept, err = sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Expected sweep to succeed but got: %v", err)
+	}
+
+	kinds := []string{"host", "origin", "certificate"}
+	for i, resource := range swept {
+		if resource.Err != nil || resource.Kind != kinds[i] {
+			t.Fatalf("Expected hosts, origins then certificates to be deleted but got %+v", swept)
+		}
+	}
+
+	origins := s.Origins(account)
+	if len(origins) != 2 || origins[0].Name != DefaultSweepPrefix+" 02" || origins[1].Name != "Production origin" {
+		t.Fatalf("Expected the recent and unprefixed origins to remain but got %+v", origins)
+	}
+	if len(s.Hosts(account)) != 0 {
+		t.Fatalf("Expected the leaked host to be deleted")
+	}
+	if certs := s.Certificates(account); len(certs) != 2 || certs[0].CommonName != "www.example.com" || certs[1].CommonName != "foo-integration.example.com" {
+		t.Fatalf("Expected only the non-integration certificates to remain but got %+v", certs)
+	}
+}
+
+func TestHasSuffix(t *testing.T) {
+	var testSuite = []struct {
+		name     string
+		expected bool
+	}{
+		{name: "integration.example.com", expected: true},
+		{name: "www.integration.example.com", expected: true},
+		{name: "Integration.Example.com", expected: true},
+		{name: "foo-integration.example.com", expected: false},
+		{name: "example.com", expected: false},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			if found := hasSuffix(tt.name, []string{"integration.example.com"}); found != tt.expected {
+				t.Fatalf("Expected hasSuffix(%s) to be %v", tt.name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSweeperSkipsCertificatesWithoutSuffixes(t *testing.T) {
+	hostsMock := &mocks.Hosts{
+		ListFunc: func(ctx context.Context, accountHash string) (*models.HostList, error) {
+			return &models.HostList{}, nil
+		},
+	}
+	originsMock := &mocks.Origins{
+		ListFunc: func(ctx context.Context, accountHash string) (*models.OriginList, error) {
+			return &models.OriginList{List: []models.Origin{{ID: 1, Name: DefaultSweepPrefix + " 01"}}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, accountHash string, originID int) error {
+			return nil
+		},
+	}
+	// Certificates are never listed, an unscripted mock method panics
+	certificatesMock := &mocks.Certificates{}
+
+	swept, err := NewWithServices("account", originsMock, certificatesMock, hostsMock).Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Expected sweep to succeed but got: %v", err)
+	}
+	if len(swept) != 1 || len(originsMock.DeleteCalls()) != 1 || originsMock.DeleteCalls()[0].AccountHash != "account" {
+		t.Fatalf("Expected the prefixed origin to be deleted but got %+v", swept)
+	}
+}
+
+func TestSweeperSince(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	hostsMock := &mocks.Hosts{
+		ListFunc: func(ctx context.Context, accountHash string) (*models.HostList, error) {
+			return &models.HostList{}, nil
+		},
+	}
+	originsMock := &mocks.Origins{
+		ListFunc: func(ctx context.Context, accountHash string) (*models.OriginList, error) {
+			return &models.OriginList{List: []models.Origin{
+				{ID: 1, Name: DefaultSweepPrefix + " 01", CreatedDate: now.Add(-2 * time.Hour).Format(time.RFC3339)},
+				{ID: 2, Name: DefaultSweepPrefix + " 02", CreatedDate: now.Add(-time.Minute).Format(time.RFC3339)},
+				{ID: 3, Name: DefaultSweepPrefix + " 03"},
+			}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, accountHash string, originID int) error {
+			return nil
+		},
+	}
+
+	os.Setenv(SweepSinceEnv, now.Add(-10*time.Minute).Format(time.RFC3339))
+	defer os.Unsetenv(SweepSinceEnv)
+
+	sweeper := NewWithServices("account", originsMock, &mocks.Certificates{}, hostsMock)
+	if err := sweeper.configureFromEnv(); err != nil {
+		t.Fatalf("Expected %s to load but got: %v", SweepSinceEnv, err)
+	}
+	sweeper.now = func() time.Time { return now }
+
+	swept, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Expected sweep to succeed but got: %v", err)
+	}
+	if len(swept) != 1 || swept[0].ID != "2" {
+		t.Fatalf("Expected only the origin created since the cutoff to be swept but got %+v", swept)
+	}
+}
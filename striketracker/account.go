@@ -0,0 +1,45 @@
+package striketracker
+
+import (
+	"errors"
+
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+)
+
+// DefaultAccount may be passed as the account hash to any service method
+// to use the client's default account
+//
+//  list, err := origins.List(ctx, striketracker.DefaultAccount)
+const DefaultAccount = ""
+
+// ErrNoAccountHash is returned when a call needs an account hash but none
+// was given and the client has no default account
+var ErrNoAccountHash = errors.New("no account hash given and no default account configured")
+
+// ForAccount returns a view of the client bound to a default account
+//
+// Services built from the view use accountHash whenever DefaultAccount is
+// passed, while explicit hashes still override it for parent and
+// sub-account work.
+//
+// The view shares with the client, and every other view, the connection
+// pool, rate limiter, identity and token source, default headers, response
+// cache and dry-run records, so SetToken and SetHeader on either affect
+// both. Exported fields such as Debug, BaseURL and RetryPolicy are copied
+// and may be changed on the view alone. The middleware is rebuilt around
+// the view so it runs with the view's settings.
+//
+//  sub := origin.New(c.ForAccount(subAccountHash))
+//  list, err := sub.List(ctx, striketracker.DefaultAccount)
+func (c *Client) ForAccount(accountHash string) *Client {
+	view := *c
+	view.AccountHash = accountHash
+	view.handler = chain(view.do, view.middleware)
+	return &view
+}
+
+// missingAccount reports whether an account scoped URL was formatted
+// without an account hash
+func missingAccount(URL string) bool {
+	return endpoints.MissingAccount(URL)
+}
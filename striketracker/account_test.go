@@ -0,0 +1,130 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+)
+
+func TestDefaultAccountAndViews(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"list":[]}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithBaseURL(server.URL),
+		WithAccountHash("parent"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	ctx := context.Background()
+	parent := c.NewEndpoint(endpoints.Origins, "/origins")
+	child := c.ForAccount("child").NewEndpoint(endpoints.Origins, "/origins")
+
+	calls := []string{
+		parent.Format(DefaultAccount),
+		child.Format(DefaultAccount),
+		child.Format("explicit"),
+	}
+	for _, URL := range calls {
+		if _, err = c.Call(ctx, GET, URL, nil, nil, nil); err != nil {
+			t.Fatalf("Expected call to %s to succeed but got: %v", URL, err)
+		}
+	}
+
+	expected := []string{
+		"/api/v1/accounts/parent/origins",
+		"/api/v1/accounts/child/origins",
+		"/api/v1/accounts/explicit/origins",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Fatalf("Expected request %d to %s but got %s", i, expected[i], paths[i])
+		}
+	}
+
+	if c.AccountHash != "parent" {
+		t.Fatalf("Expected ForAccount to leave the original client untouched but found %s", c.AccountHash)
+	}
+}
+
+func TestMissingAccountHash(t *testing.T) {
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	URL := c.NewEndpoint(endpoints.Origins, "/origins").Format(DefaultAccount)
+	if _, err = c.Call(context.Background(), GET, URL, nil, nil, nil); err != ErrNoAccountHash {
+		t.Fatalf("Expected ErrNoAccountHash without an account but got: %v", err)
+	}
+}
+
+func TestForAccountUsesViewSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var logged []string
+	var seen []string
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithBaseURL(server.URL),
+		WithAccountHash("parent"),
+		WithLogger(LoggerFunc(func(msg string, fields Fields) {
+			mu.Lock()
+			defer mu.Unlock()
+			logged = append(logged, fields["url"].(string))
+		})),
+		WithMiddleware(func(next Handler) Handler {
+			return func(req *http.Request, v interface{}) (*http.Response, error) {
+				mu.Lock()
+				seen = append(seen, req.URL.Path)
+				mu.Unlock()
+				return next(req, v)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	view := c.ForAccount("child")
+	view.Debug = true
+
+	ctx := context.Background()
+	for _, client := range []*Client{c, view} {
+		URL := client.NewEndpoint(endpoints.Origins, "/origins").Format(DefaultAccount)
+		if _, err = client.Call(ctx, GET, URL, nil, nil, nil); err != nil {
+			t.Fatalf("Expected call to %s to succeed but got: %v", URL, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 2 {
+		t.Fatalf("Expected middleware to run for the client and the view but saw %v", seen)
+	}
+	if len(logged) != 1 || logged[0] != server.URL+"/api/v1/accounts/child/origins" {
+		t.Fatalf("Expected only the debugging view to log but got %v", logged)
+	}
+}
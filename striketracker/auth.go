@@ -1,10 +1,24 @@
 package striketracker
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
-// TODO
-// Implement optional user/pass access token authentication
-// for non-permanent auth token usage
+	"github.com/openwurl/wurlwind/striketracker/identity"
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+// AuthenticatePath is the OAuth token endpoint, which sits outside the API version
+const AuthenticatePath = "/auth/token"
+
+// DefaultRefreshWindow is how long before expiry an access token is refreshed
+const DefaultRefreshWindow = time.Minute
 
 // Authorization manages the bearer and auth tokens
 type Authorization struct {
@@ -15,3 +29,152 @@ type Authorization struct {
 func (a *Authorization) GetBearer() string {
 	return fmt.Sprintf("Bearer %s", a.authorizationHeaderToken)
 }
+
+// PasswordTokenSource logs in with a username and password via
+// POST /auth/token and refreshes the access token before it expires
+//
+// Login failures are returned as *APIError so callers can tell
+// ErrPasswordExpired and ErrNotWhitelisted apart with errors.Is
+type PasswordTokenSource struct {
+	// RefreshWindow is how long before expiry the token is refreshed
+	RefreshWindow time.Duration
+
+	client   *Client
+	username string
+	password string
+
+	mu         sync.Mutex
+	token      *identity.Token
+	refreshing *tokenRefresh
+}
+
+// tokenRefresh is a login or refresh in progress, shared by every caller
+// which finds the token expired until done is closed
+type tokenRefresh struct {
+	done  chan struct{}
+	token *identity.Token
+	err   error
+}
+
+// refreshingKey marks the context of a grant so requests sent through the
+// client while it is in flight, such as by middleware, do not wait on it
+type refreshingKey struct{}
+
+// NewPasswordTokenSource returns a TokenSource which authenticates through c
+func NewPasswordTokenSource(c *Client, username string, password string) *PasswordTokenSource {
+	return &PasswordTokenSource{
+		RefreshWindow: DefaultRefreshWindow,
+		client:        c,
+		username:      username,
+		password:      password,
+	}
+}
+
+// Token returns a valid access token, logging in or refreshing as needed
+//
+// Concurrent callers share a single login, which is sent without holding
+// the lock. Requests made through the client during the login, such as by
+// middleware, are given the current token, which may be expired or nil.
+func (s *PasswordTokenSource) Token(ctx context.Context) (*identity.Token, error) {
+	for {
+		s.mu.Lock()
+		if !s.token.Expired(s.RefreshWindow) || ctx.Value(refreshingKey{}) == s {
+			token := s.token
+			s.mu.Unlock()
+			return token, nil
+		}
+
+		if r := s.refreshing; r != nil {
+			s.mu.Unlock()
+			select {
+			case <-r.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			// Try again if the login was abandoned by its own caller
+			if r.err != nil && (errors.Is(r.err, context.Canceled) || errors.Is(r.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+				continue
+			}
+			return r.token, r.err
+		}
+
+		r := &tokenRefresh{done: make(chan struct{})}
+		s.refreshing = r
+		current := s.token
+		s.mu.Unlock()
+
+		r.token, r.err = s.refresh(context.WithValue(ctx, refreshingKey{}, s), current)
+
+		s.mu.Lock()
+		if r.err == nil {
+			s.token = r.token
+		}
+		s.refreshing = nil
+		s.mu.Unlock()
+		close(r.done)
+
+		return r.token, r.err
+	}
+}
+
+// refresh exchanges the refresh token of current, falling back to a fresh
+// login if there is none or it was rejected
+func (s *PasswordTokenSource) refresh(ctx context.Context, current *identity.Token) (*identity.Token, error) {
+	if current != nil && current.RefreshToken != "" {
+		token, err := s.grant(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {current.RefreshToken},
+		})
+		if err == nil {
+			return token, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	token, err := s.grant(ctx, url.Values{
+		"grant_type": {"password"},
+		"username":   {s.username},
+		"password":   {s.password},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authenticating %s: %w", s.username, err)
+	}
+	return token, nil
+}
+
+// grant requests a token from the OAuth endpoint
+//
+// The request is built by hand so it carries no Authorization header,
+// but still passes through the client's middleware, retries and logging
+func (s *PasswordTokenSource) grant(ctx context.Context, form url.Values) (*identity.Token, error) {
+	req, err := http.NewRequest(POST.String(), s.client.BaseURL+AuthenticatePath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	// Logging in is never skipped by dry-run mode, reads still need a token
+	req = req.WithContext(withoutDryRun(ctx))
+
+	s.client.headers.apply(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	answer := &models.AuthToken{}
+	if _, err = s.client.DoRequest(req, answer); err != nil {
+		return nil, err
+	}
+
+	if answer.AccessToken == "" {
+		return nil, fmt.Errorf("no access token returned from %s", AuthenticatePath)
+	}
+
+	token := &identity.Token{
+		AccessToken:  answer.AccessToken,
+		RefreshToken: answer.RefreshToken,
+	}
+	if answer.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(answer.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
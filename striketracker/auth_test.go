@@ -0,0 +1,238 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	TestUsername = "integration@wurl.com"
+	TestPassword = "hunter2hunter2"
+)
+
+// oauthTestServer emulates /auth/token and a protected endpoint
+type oauthTestServer struct {
+	mu        sync.Mutex
+	grants    []string
+	failCode  int
+	issued    int
+	lastToken string
+	delay     time.Duration
+}
+
+func (o *oauthTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	time.Sleep(o.delay)
+
+	if r.URL.Path == AuthenticatePath {
+		r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		o.grants = append(o.grants, grant)
+
+		if o.failCode != 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"login rejected","code":` + strconv.Itoa(o.failCode) + `}`))
+			return
+		}
+
+		if grant == "password" && (r.PostForm.Get("username") != TestUsername || r.PostForm.Get("password") != TestPassword) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Resource requires authentication but user is not authenticated","code":203}`))
+			return
+		}
+
+		o.issued++
+		o.lastToken = "access-" + strconv.Itoa(o.issued)
+		w.Write([]byte(`{"access_token":"` + o.lastToken + `","refresh_token":"refresh","expires_in":3600,"token_type":"bearer"}`))
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+o.lastToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Resource requires authentication but user is not authenticated","code":203}`))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestPasswordTokenSource(t *testing.T) {
+	o := &oauthTestServer{}
+	server := httptest.NewServer(o)
+	defer server.Close()
+
+	var logged []string
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithPasswordCredentials(TestUsername, TestPassword),
+		WithBaseURL(server.URL),
+		WithDebug(true),
+		WithLogger(LoggerFunc(func(msg string, fields Fields) {
+			if body, ok := fields["request_body"].(string); ok {
+				logged = append(logged, body)
+			}
+		})),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure with password credentials but got: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Login happens transparently on the first request
+	if _, err = c.Call(ctx, GET, server.URL+"/api/v1/accounts", nil, nil, nil); err != nil {
+		t.Fatalf("Expected request to authenticate with password grant but got: %v", err)
+	}
+
+	// Token is reused while valid
+	if _, err = c.Call(ctx, GET, server.URL+"/api/v1/accounts", nil, nil, nil); err != nil {
+		t.Fatalf("Expected request to reuse the access token but got: %v", err)
+	}
+
+	// Refresh once the token enters the refresh window
+	c.Identity.Source.(*PasswordTokenSource).RefreshWindow = 2 * time.Hour
+	if _, err = c.Call(ctx, GET, server.URL+"/api/v1/accounts", nil, nil, nil); err != nil {
+		t.Fatalf("Expected request to use refreshed token but got: %v", err)
+	}
+
+	o.mu.Lock()
+	grants := strings.Join(o.grants, ",")
+	o.mu.Unlock()
+	if grants != "password,refresh_token" {
+		t.Fatalf("Expected a password grant followed by a refresh but got %s", grants)
+	}
+
+	for _, body := range logged {
+		if strings.Contains(body, TestPassword) || strings.Contains(body, "refresh_token=refresh") {
+			t.Fatalf("Expected credentials to be redacted from debug logs but found: %s", body)
+		}
+	}
+}
+
+func TestPasswordTokenSourceErrors(t *testing.T) {
+	var testSuite = []struct {
+		name   string
+		code   int
+		target error
+	}{
+		{name: "password expired", code: 204, target: ErrPasswordExpired},
+		{name: "not whitelisted", code: 206, target: ErrNotWhitelisted},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &oauthTestServer{failCode: tt.code}
+			server := httptest.NewServer(o)
+			defer server.Close()
+
+			c, err := NewClientWithOptions(
+				WithApplicationID(TestID),
+				WithPasswordCredentials(TestUsername, TestPassword),
+				WithBaseURL(server.URL),
+			)
+			if err != nil {
+				t.Fatalf("Expected client to configure with password credentials but got: %v", err)
+			}
+
+			_, err = c.Call(context.Background(), GET, server.URL+"/api/v1/accounts", nil, nil, nil)
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("Expected login to fail with %v but got: %v", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestPasswordTokenSourceConcurrentLogin(t *testing.T) {
+	o := &oauthTestServer{delay: 20 * time.Millisecond}
+	server := httptest.NewServer(o)
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithPasswordCredentials(TestUsername, TestPassword),
+		WithBaseURL(server.URL),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure with password credentials but got: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Call(context.Background(), GET, server.URL+"/api/v1/accounts", nil, nil, nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected every request to share the login but got: %v", err)
+		}
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.grants) != 1 {
+		t.Fatalf("Expected a single login for concurrent requests but got %v", o.grants)
+	}
+}
+
+func TestPasswordTokenSourceReentrantMiddleware(t *testing.T) {
+	o := &oauthTestServer{}
+	server := httptest.NewServer(o)
+	defer server.Close()
+
+	var c *Client
+	var audited int32
+	audit := func(next Handler) Handler {
+		return func(req *http.Request, v interface{}) (*http.Response, error) {
+			if req.URL.Path == AuthenticatePath && atomic.AddInt32(&audited, 1) == 1 {
+				// Sent through the same client while the login is in flight
+				c.Call(req.Context(), GET, server.URL+"/api/v1/audit", nil, nil, nil)
+			}
+			return next(req, v)
+		}
+	}
+
+	var err error
+	c, err = NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithPasswordCredentials(TestUsername, TestPassword),
+		WithBaseURL(server.URL),
+		WithMiddleware(audit),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure with password credentials but got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err = c.Call(ctx, GET, server.URL+"/api/v1/accounts", nil, nil, nil); err != nil {
+		t.Fatalf("Expected login with a re-entrant middleware to succeed but got: %v", err)
+	}
+	if atomic.LoadInt32(&audited) != 1 {
+		t.Fatalf("Expected the middleware to audit the login once")
+	}
+}
+
+func TestPasswordCredentialsValidation(t *testing.T) {
+	_, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithPasswordCredentials(TestUsername, ""),
+	)
+	if err == nil {
+		t.Fatalf("Expected username without password to fail validation")
+	}
+}
@@ -0,0 +1,162 @@
+package striketracker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBatchWorkers is the number of operations run at once when no
+// worker count is given
+const DefaultBatchWorkers = 4
+
+// BatchOperation is a single unit of work in a batch, such as one
+// origin.Service.Create call, returning the resulting model
+type BatchOperation func(ctx context.Context) (interface{}, error)
+
+// BatchResult is the outcome of one operation, Index is its position in the batch
+type BatchResult struct {
+	Index    int
+	Value    interface{}
+	Err      error
+	Duration time.Duration
+}
+
+// BatchReport holds a result for every operation in batch order
+type BatchReport struct {
+	Results []BatchResult
+}
+
+// Succeeded returns the results of operations which completed without error
+func (r *BatchReport) Succeeded() []BatchResult {
+	var out []BatchResult
+	for _, result := range r.Results {
+		if result.Err == nil {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// Failed returns the results of operations which failed or never ran
+func (r *BatchReport) Failed() []BatchResult {
+	var out []BatchResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// Err returns a *BatchError describing every failure, or nil when all succeeded
+func (r *BatchReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BatchError{Total: len(r.Results), Failures: failed}
+}
+
+// BatchError summarises the failed operations of a batch
+type BatchError struct {
+	Total    int
+	Failures []BatchResult
+}
+
+// Error lists each failed operation by index
+func (e *BatchError) Error() string {
+	reasons := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		reasons[i] = fmt.Sprintf("[%d] %v", failure.Index, failure.Err)
+	}
+	return fmt.Sprintf("%d of %d batch operations failed: %s", len(e.Failures), e.Total, strings.Join(reasons, ", "))
+}
+
+// BatchPanicError reports an operation which panicked
+type BatchPanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error describes the panic value
+func (e *BatchPanicError) Error() string {
+	return fmt.Sprintf("batch operation panicked: %v", e.Value)
+}
+
+// runOperation runs a single operation, recording a panic as its error
+func runOperation(ctx context.Context, i int, op BatchOperation) (result BatchResult) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result = BatchResult{Index: i, Err: &BatchPanicError{Value: r, Stack: debug.Stack()}, Duration: time.Since(start)}
+		}
+	}()
+
+	value, err := op(ctx)
+	return BatchResult{Index: i, Value: value, Err: err, Duration: time.Since(start)}
+}
+
+// RunBatch runs the operations with up to workers at a time, defaulting to
+// DefaultBatchWorkers, and reports the result of every operation rather
+// than stopping at the first error
+//
+// Requests made by the operations still pass through the client's rate
+// limiter, so the worker count bounds concurrency while the limiter bounds
+// throughput. An operation which panics is reported with a *BatchPanicError
+// rather than crashing the process. Once ctx is done no further operations are started and those
+// remaining are reported with the context's error. Operations share ctx,
+// so anything written through it, such as WithResponseMetadata, must be
+// attached per operation instead.
+//
+//  ops := make([]striketracker.BatchOperation, len(origins))
+//  for i, o := range origins {
+//  	o := o
+//  	ops[i] = func(ctx context.Context) (interface{}, error) {
+//  		return originService.Create(ctx, accountHash, o)
+//  	}
+//  }
+//  report := striketracker.RunBatch(ctx, 8, ops)
+//  if err := report.Err(); err != nil {
+//  	// retry report.Failed()
+//  }
+func RunBatch(ctx context.Context, workers int, ops []BatchOperation) *BatchReport {
+	if workers < 1 {
+		workers = DefaultBatchWorkers
+	}
+
+	report := &BatchReport{Results: make([]BatchResult, len(ops))}
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(ops); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				report.Results[i] = runOperation(ctx, i, ops[i])
+			}
+		}()
+	}
+
+	next := 0
+dispatch:
+	for ; next < len(ops) && ctx.Err() == nil; next++ {
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < len(ops); i++ {
+		report.Results[i] = BatchResult{Index: i, Err: ctx.Err()}
+	}
+
+	return report
+}
@@ -0,0 +1,106 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatch(t *testing.T) {
+	var running, peak int32
+	ops := make([]BatchOperation, 20)
+	for i := range ops {
+		i := i
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if i%5 == 0 {
+				return nil, ErrDuplicateOrigin
+			}
+			return i, nil
+		}
+	}
+
+	report := RunBatch(context.Background(), 3, ops)
+
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Fatalf("Expected at most 3 operations at once but saw %d", p)
+	}
+	if len(report.Results) != 20 || len(report.Succeeded()) != 16 || len(report.Failed()) != 4 {
+		t.Fatalf("Expected 16 successes and 4 failures but got %d and %d", len(report.Succeeded()), len(report.Failed()))
+	}
+	for i, result := range report.Results {
+		if result.Index != i {
+			t.Fatalf("Expected results in batch order but result %d has index %d", i, result.Index)
+		}
+		if result.Err == nil && result.Value != i {
+			t.Fatalf("Expected result %d to hold its value but got %v", i, result.Value)
+		}
+	}
+
+	var batchErr *BatchError
+	if err := report.Err(); !errors.As(err, &batchErr) || batchErr.Total != 20 || len(batchErr.Failures) != 4 {
+		t.Fatalf("Expected a *BatchError describing 4 of 20 failures but got %v", err)
+	}
+	if !errors.Is(report.Failed()[0].Err, ErrDuplicateOrigin) {
+		t.Fatalf("Expected failures to keep their API error but got %v", report.Failed()[0].Err)
+	}
+}
+
+func TestRunBatchCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started int32
+	ops := make([]BatchOperation, 10)
+	for i := range ops {
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			if atomic.AddInt32(&started, 1) == 2 {
+				cancel()
+			}
+			return nil, nil
+		}
+	}
+
+	report := RunBatch(ctx, 1, ops)
+
+	if n := atomic.LoadInt32(&started); n >= 10 {
+		t.Fatalf("Expected cancellation to stop further operations but %d ran", n)
+	}
+	last := report.Results[len(report.Results)-1]
+	if last.Err != context.Canceled {
+		t.Fatalf("Expected operations never started to report context.Canceled but got %v", last.Err)
+	}
+}
+
+func TestRunBatchRecoversPanics(t *testing.T) {
+	ops := []BatchOperation{
+		func(ctx context.Context) (interface{}, error) { return "ok", nil },
+		func(ctx context.Context) (interface{}, error) { panic("nil origin") },
+		func(ctx context.Context) (interface{}, error) { return "ok", nil },
+	}
+
+	report := RunBatch(context.Background(), 2, ops)
+	if len(report.Succeeded()) != 2 {
+		t.Fatalf("Expected the other operations to succeed but got %+v", report.Results)
+	}
+
+	var panicErr *BatchPanicError
+	if !errors.As(report.Results[1].Err, &panicErr) || panicErr.Value != "nil origin" || len(panicErr.Stack) == 0 {
+		t.Fatalf("Expected the panic to be reported for its operation but got %v", report.Results[1].Err)
+	}
+
+	var batchErr *BatchError
+	if !errors.As(report.Err(), &batchErr) || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 {
+		t.Fatalf("Expected the panic in the batch error but got %v", report.Err())
+	}
+}
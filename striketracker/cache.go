@@ -0,0 +1,253 @@
+package striketracker
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+)
+
+// CacheHeader is set on responses served from the response cache,
+// "hit" when served without a request and "revalidated" after a 304
+const CacheHeader = "X-Wurlwind-Cache"
+
+// errNotCached is returned by update for a 304 whose entry was invalidated
+// after the conditional request was made
+var errNotCached = errors.New("304 Not Modified received with no cached response to answer it")
+
+// cacheKey identifies a cached GET by account and full URL
+type cacheKey struct {
+	account string
+	url     string
+}
+
+// cacheEntry is a cached successful response
+type cacheEntry struct {
+	path         string
+	header       http.Header
+	body         []byte
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+// ResponseCache stores successful GET responses for a TTL, revalidating
+// with If-None-Match or If-Modified-Since once stale when Striketracker
+// supplied an ETag or Last-Modified
+//
+// Any successful POST, PUT, PATCH or DELETE invalidates entries of the
+// same account whose path is the mutated path, beneath it or above it,
+// so a change to an origin also drops the cached origin listing
+type ResponseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	now     func() time.Time
+}
+
+// NewResponseCache returns a cache holding responses for ttl
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttl:     ttl,
+		entries: make(map[cacheKey]*cacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Purge discards every cached response
+func (rc *ResponseCache) Purge() {
+	if rc == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[cacheKey]*cacheEntry)
+}
+
+// Len returns the number of cached responses
+func (rc *ResponseCache) Len() int {
+	if rc == nil {
+		return 0
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return len(rc.entries)
+}
+
+// Invalidate discards cached responses related to the path of URL, as a
+// mutating call to it would
+func (rc *ResponseCache) Invalidate(URL string) {
+	if rc == nil {
+		return
+	}
+
+	u, err := url.Parse(URL)
+	if err != nil {
+		return
+	}
+	rc.invalidate(accountOf(u.Path), u.Path)
+}
+
+// Cache returns the client's response cache, nil unless enabled with
+// WithResponseCache, which is shared with its account views
+func (c *Client) Cache() *ResponseCache {
+	return c.cache
+}
+
+// lookup returns a fresh cached response for req, or adds conditional
+// headers to a copy of req when a stale entry can be revalidated
+func (rc *ResponseCache) lookup(req *http.Request) (*http.Request, *http.Response) {
+	if rc == nil || req.Method != GET.String() {
+		return req, nil
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[keyOf(req)]
+	if !ok {
+		return req, nil
+	}
+
+	if rc.now().Before(entry.expires) {
+		return req, entry.response(req, "hit")
+	}
+
+	if entry.etag == "" && entry.lastModified == "" {
+		delete(rc.entries, keyOf(req))
+		return req, nil
+	}
+
+	req = req.Clone(req.Context())
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	return req, nil
+}
+
+// update stores successful GET responses, answers a 304 from the cached
+// body and invalidates related entries after successful mutations
+//
+// errNotCached is returned for a 304 whose entry is gone, such as after a
+// concurrent invalidation, so the request can be repeated unconditionally
+func (rc *ResponseCache) update(req *http.Request, resp *http.Response, body []byte) (*http.Response, []byte, error) {
+	if rc == nil || resp.Header.Get(CacheHeader) == "hit" {
+		return resp, body, nil
+	}
+
+	if isMutating(req.Method) {
+		// Dry-run responses changed nothing on Striketracker
+		if resp.StatusCode < 400 && resp.Header.Get(DryRunHeader) == "" {
+			rc.invalidate(accountOf(req.URL.Path), req.URL.Path)
+		}
+		return resp, body, nil
+	}
+
+	if req.Method != GET.String() {
+		return resp, body, nil
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	key := keyOf(req)
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		entry, ok := rc.entries[key]
+		if !ok {
+			return resp, body, errNotCached
+		}
+		entry.expires = rc.now().Add(rc.ttl)
+		return entry.response(req, "revalidated"), entry.body, nil
+	case resp.StatusCode == http.StatusOK:
+		if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+			delete(rc.entries, key)
+			return resp, body, nil
+		}
+		rc.entries[key] = &cacheEntry{
+			path:         req.URL.Path,
+			header:       resp.Header.Clone(),
+			body:         body,
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			expires:      rc.now().Add(rc.ttl),
+		}
+	}
+	return resp, body, nil
+}
+
+// unconditional returns a copy of req without the revalidation headers
+// added by lookup
+func unconditional(req *http.Request) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+	return req
+}
+
+// invalidate drops entries of account whose path is related to path
+func (rc *ResponseCache) invalidate(account string, path string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key, entry := range rc.entries {
+		if key.account == account && (pathWithin(entry.path, path) || pathWithin(path, entry.path)) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// response builds a response for req from the cached entry
+func (e *cacheEntry) response(req *http.Request, state string) *http.Response {
+	header := e.header.Clone()
+	header.Set(CacheHeader, state)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// keyOf returns the cache key of a request
+func keyOf(req *http.Request) cacheKey {
+	return cacheKey{account: accountOf(req.URL.Path), url: req.URL.String()}
+}
+
+// accountOf returns the account hash of an account scoped path
+func accountOf(path string) string {
+	marker := endpoints.ACCOUNTS + "/"
+	i := strings.Index(path, marker)
+	if i < 0 {
+		return ""
+	}
+	account := path[i+len(marker):]
+	if j := strings.Index(account, "/"); j >= 0 {
+		account = account[:j]
+	}
+	return account
+}
+
+// pathWithin reports whether path is parent or one of its descendants
+func pathWithin(path string, parent string) bool {
+	parent = strings.TrimSuffix(parent, "/")
+	path = strings.TrimSuffix(path, "/")
+	return path == parent || strings.HasPrefix(path, parent+"/")
+}
@@ -0,0 +1,160 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	var requests, revalidated int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	config := *BaseConfiguration
+	config.CacheTTL = 60
+	c, err := NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	now := time.Now()
+	c.Cache().now = func() time.Time { return now }
+
+	list := server.URL + "/api/v1/accounts/abc/origins"
+	get := func(URL string) (*http.Response, *validatedModel) {
+		out := &validatedModel{}
+		resp, err := c.Call(context.Background(), GET, URL, nil, nil, out)
+		if err != nil {
+			t.Fatalf("Expected GET %s to succeed but got: %v", URL, err)
+		}
+		return resp, out
+	}
+
+	get(list)
+	resp, out := get(list)
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("Expected second read to be served from cache but server saw %d requests", n)
+	}
+	if resp.Header.Get(CacheHeader) != "hit" || out.Name != "/api/v1/accounts/abc/origins" {
+		t.Fatalf("Expected a cache hit with the cached body but got %s %v", resp.Header.Get(CacheHeader), out)
+	}
+
+	// Other accounts are cached separately
+	get(server.URL + "/api/v1/accounts/def/origins")
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatalf("Expected another account to miss the cache but server saw %d requests", n)
+	}
+
+	// Stale entries are revalidated with their ETag
+	now = now.Add(2 * time.Minute)
+	resp, out = get(list)
+	if atomic.LoadInt32(&revalidated) != 1 || resp.Header.Get(CacheHeader) != "revalidated" {
+		t.Fatalf("Expected a conditional request once stale but got %s", resp.Header.Get(CacheHeader))
+	}
+	if out.Name != "/api/v1/accounts/abc/origins" {
+		t.Fatalf("Expected a 304 to be answered from the cached body but got %v", out)
+	}
+
+	// Mutations invalidate the listing of the same account only
+	before := atomic.LoadInt32(&requests)
+	if _, err = c.Call(context.Background(), DELETE, list+"/1", nil, nil, nil); err != nil {
+		t.Fatalf("Expected DELETE to succeed but got: %v", err)
+	}
+	if c.Cache().Len() != 1 {
+		t.Fatalf("Expected only the other account's entry to remain but found %d", c.Cache().Len())
+	}
+	get(list)
+	if n := atomic.LoadInt32(&requests); n != before+2 {
+		t.Fatalf("Expected the listing to be fetched again after a mutation but server saw %d requests", n-before)
+	}
+
+	c.Cache().Purge()
+	if c.Cache().Len() != 0 {
+		t.Fatalf("Expected purge to empty the cache")
+	}
+}
+
+func TestResponseCacheRevalidationMiss(t *testing.T) {
+	var c *Client
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			// A concurrent mutation drops the entry before the 304 arrives
+			c.Cache().Invalidate("http://" + r.Host + r.URL.Path)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"name":"fresh"}`))
+	}))
+	defer server.Close()
+
+	config := *BaseConfiguration
+	config.CacheTTL = 60
+	var err error
+	if c, err = NewClient(&config); err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	now := time.Now()
+	c.Cache().now = func() time.Time { return now }
+
+	origin := server.URL + "/api/v1/accounts/abc/origins/1"
+	if _, err = c.Call(context.Background(), GET, origin, nil, nil, &validatedModel{}); err != nil {
+		t.Fatalf("Expected GET to succeed but got: %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	out := &validatedModel{}
+	if _, err = c.Call(context.Background(), GET, origin, nil, nil, out); err != nil {
+		t.Fatalf("Expected GET after a revalidation miss to succeed but got: %v", err)
+	}
+	if out.Name != "fresh" {
+		t.Fatalf("Expected the request to be repeated without conditions but decoded %v", out)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Fatalf("Expected an unconditional request after the 304 but server saw %d requests", n)
+	}
+}
+
+func TestResponseCacheIgnoresDryRunMutations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"cached"}`))
+	}))
+	defer server.Close()
+
+	config := *BaseConfiguration
+	config.CacheTTL = 60
+	config.DryRun = true
+	c, err := NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	list := server.URL + "/api/v1/accounts/abc/origins"
+	if _, err = c.Call(context.Background(), GET, list, nil, nil, &validatedModel{}); err != nil {
+		t.Fatalf("Expected GET to succeed but got: %v", err)
+	}
+	if _, err = c.Call(context.Background(), DELETE, list+"/1", nil, nil, nil); err != nil {
+		t.Fatalf("Expected dry-run DELETE to succeed but got: %v", err)
+	}
+	if c.Cache().Len() != 1 {
+		t.Fatalf("Expected a dry-run mutation to leave the cache untouched but found %d entries", c.Cache().Len())
+	}
+}
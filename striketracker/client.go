@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
 	"github.com/openwurl/wurlwind/striketracker/identity"
 )
 
@@ -23,78 +25,158 @@ type Client struct {
 	Debug bool
 	//Auth          *auth.Wrapper
 	Identity      *identity.Identification
-	c             *http.Client // TODO don't use DefaultClient and allow injectable
+	c             *http.Client
 	ApplicationID string
-	Headers       []*Header
+	userAgent     string
+	headers       *headerSet
+	BaseURL       string
+	APIVersion    string
+	AccountHash   string
+	RetryPolicy   *RetryPolicy
+	limiter       *RateLimiter
+	logger        Logger
+	handler       Handler
+	middleware    []Middleware
+	cache         *ResponseCache
+
+	strictDecoding bool
+	unknownFields  UnknownFieldsHandler
+
+	dryRunRecorder *dryRunRecorder
 }
 
 // NewClientFromConfiguration will validate configuration and return a configured client
+//
+// A configuration file or profile selected on config or by environment is
+// loaded beneath it, as for NewClientWithOptions, with non-zero fields of
+// config taking precedence over the profile. A false debug, dryRun or
+// strictDecoding cannot be told apart from an unset one, to turn off a
+// profile's setting build config with NewConfiguration and WithDebug(false),
+// WithDryRun(false) or WithStrictDecoding(false), whose options are
+// re-applied over the profile.
 func NewClientFromConfiguration(config *Configuration) (*Client, error) {
-	err := config.Validate()
-	if err != nil {
-		return nil, err
-	}
-
-	client, err := NewClient(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return client, nil
+	return newClientFromConfiguration(config, nil)
 }
 
 // NewClientWithOptions validates configuration and returns a configured client from functional parameters
+//
+// If a configuration file or profile is selected by option or environment
+// the profile is loaded first and the options are applied over it. Credential
+// providers are then walked in order if no token was given.
 func NewClientWithOptions(opts ...Option) (*Client, error) {
 	options := &Configuration{}
 	for _, opt := range opts {
 		opt(options)
 	}
 
-	err := options.Validate()
+	return newClientFromConfiguration(options, opts)
+}
+
+// newClientFromConfiguration loads the selected profile, resolves credentials,
+// validates and builds the client
+//
+// The options which produced config are re-applied over the profile when
+// given, otherwise the non-zero fields of config are copied over it
+func newClientFromConfiguration(config *Configuration, opts []Option) (*Client, error) {
+	if config.usesConfigFile() {
+		profile, err := LoadConfigurationFile(config.ConfigFile, config.Profile)
+		if err != nil {
+			return nil, err
+		}
+		if opts != nil {
+			for _, opt := range opts {
+				opt(profile)
+			}
+		} else {
+			profile.overlay(config)
+		}
+		config = profile
+	}
+
+	err := config.resolveCredentials()
 	if err != nil {
 		return nil, err
 	}
 
-	c, err := NewClient(options)
+	err = config.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	return c, nil
+	return NewClient(config)
 }
 
 // NewClient returns a configured client
 func NewClient(config *Configuration) (*Client, error) {
 	// Check that authorization values are defined at all
-	if config.AuthorizationHeaderToken == "" {
-		return nil, fmt.Errorf("No authorization is defined. You need AuthorizationHeaderToken")
+	if config.AuthorizationHeaderToken == "" && config.Username == "" && config.TokenSource == nil {
+		return nil, fmt.Errorf("No authorization is defined. You need AuthorizationHeaderToken, a Username and Password, or a TokenSource")
 	}
 
 	if config.ApplicationID == "" {
 		return nil, fmt.Errorf("ApplicationID is required - this is the only way to identify your requests in highwinds logs")
 	}
 
+	hc, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Configure the client from final configuration
 	c := &Client{
-		c:             http.DefaultClient,
+		c:             hc,
 		Debug:         config.Debug,
 		ApplicationID: config.ApplicationID,
-		Identity: &identity.Identification{
-			AuthorizationHeaderToken: config.AuthorizationHeaderToken,
-		},
+		userAgent:     UserAgent(config.ApplicationID, config.UserAgentSuffix),
+		Identity:      &identity.Identification{},
+		BaseURL:       endpoints.URL,
+		APIVersion:    endpoints.V1,
+		AccountHash:   config.AccountHash,
+		RetryPolicy:   config.RetryPolicy,
+	}
+
+	if config.BaseURL != "" {
+		c.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	}
+
+	if config.APIVersion != "" {
+		c.APIVersion = config.APIVersion
+	}
+
+	c.logger = config.Logger
+	if c.logger == nil {
+		c.logger = defaultLogger()
+	}
+
+	if config.RateLimit > 0 {
+		c.limiter = NewRateLimiter(config.RateLimit, config.RateBurst)
+	}
+
+	c.middleware = config.Middleware
+	c.handler = chain(c.do, c.middleware)
+
+	c.strictDecoding = config.StrictDecoding
+	c.unknownFields = config.UnknownFieldsHandler
+
+	if config.CacheTTL > 0 {
+		c.cache = NewResponseCache(time.Second * time.Duration(config.CacheTTL))
 	}
 
-	// TODO eventually instantiate a custom client but not ready for that yet
+	if config.DryRun {
+		c.dryRunRecorder = &dryRunRecorder{}
+	}
+
+	c.Identity.SetToken(config.AuthorizationHeaderToken)
 
-	// Configure timeout on default client
-	if config.Timeout == 0 {
-		c.c.Timeout = time.Second * 10
-	} else {
-		c.c.Timeout = time.Second * time.Duration(config.Timeout)
+	// Prefer dynamic tokens over the permanent token if configured
+	if config.TokenSource != nil {
+		c.Identity.SetTokenSource(config.TokenSource)
+	} else if config.Username != "" {
+		c.Identity.SetTokenSource(NewPasswordTokenSource(c, config.Username, config.Password))
 	}
 
 	// Set default headers
-	c.Headers = c.GetHeaders()
+	c.headers = &headerSet{headers: c.GetHeaders()}
 	return c, nil
 }
 
@@ -116,36 +198,136 @@ func (c *Client) NewRequestContext(ctx context.Context, method HTTPMethod, URL s
 	}
 	req = req.WithContext(ctx)
 
-	for _, header := range c.Headers {
-		req.Header.Set(header.Key, header.Value)
-	}
+	c.headers.apply(req)
 
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Add auth token from memory if it exists
-	if c.Identity.AuthorizationHeaderToken != "" {
-		req.Header.Set("Authorization", c.Identity.GetBearer())
+	// Add auth token from memory or the token source if it exists
+	bearer, err := c.Identity.Bearer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", bearer)
 	}
 
 	return req, nil
 }
 
-// DoRequest performs the request
+// DoRequest performs the request through the configured middleware, retrying
+// transient failures if a RetryPolicy is configured
+//
+// The status is checked before decoding into v. Failed responses are returned
+// alongside an *APIError, and the raw body remains readable from the response.
 func (c *Client) DoRequest(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.c.Do(req)
+	return c.handler(req, v)
+}
+
+// validatable is implemented by models which can check themselves before sending
+type validatable interface {
+	Validate() error
+}
+
+// Call builds, validates, performs and decodes a request in one step
+//
+// URL is typically produced by an endpoints.Endpoint, query may be nil,
+// in is validated if possible and sent as the JSON body when not nil, and
+// out receives the decoded response when not nil. Failures are returned as
+// an *APIError, or ErrNoAccountHash if an account scoped URL has no account.
+//
+//  origin := &models.Origin{}
+//  _, err := c.Call(ctx, striketracker.GET, e.Segments(accountHash, "8675309"), nil, nil, origin)
+func (c *Client) Call(ctx context.Context, method HTTPMethod, URL string, query Query, in interface{}, out interface{}) (*http.Response, error) {
+	if missingAccount(URL) {
+		return nil, ErrNoAccountHash
+	}
+
+	if v, ok := in.(validatable); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := c.NewRequestContext(ctx, method, appendQuery(URL, query), in)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DoRequest(req, out)
+}
+
+// do is the innermost Handler which sends, decodes and logs the request
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
+	start := time.Now()
+	req, resp := c.cache.lookup(req)
+	var err error
+	if resp == nil {
+		resp, err = c.dryRun(req)
+	}
+	if err == nil && resp == nil {
+		resp, err = c.send(req)
+	}
 	if err != nil {
+		captureMetadata(req, nil, start)
+		c.logRequest(req, nil, nil, start, err)
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if v != nil {
-		err = json.NewDecoder(resp.Body).Decode(v)
+	body, err := readBody(resp)
+	if err == nil {
+		resp, body, err = c.cache.update(req, resp, body)
 	}
+	if err == errNotCached {
+		// The entry was invalidated while revalidating, fetch it afresh
+		req = unconditional(req)
+		if resp, err = c.send(req); err == nil {
+			body, err = readBody(resp)
+		}
+		if err == nil {
+			resp, body, err = c.cache.update(req, resp, body)
+		}
+	}
+	if err == nil {
+		err = decodeResponse(resp, body, v)
+	}
+	if err == nil {
+		err = c.checkUnknownFields(req, resp, body, v)
+	}
+	captureMetadata(req, resp, start)
+	c.logRequest(req, resp, body, start, err)
+
 	return resp, err
 }
 
+// NewEndpoint returns an endpoint rooted at the client's base URL and API version
+// which falls back to the client's default account
+func (c *Client) NewEndpoint(basePath endpoints.BasePath, path string) *endpoints.Endpoint {
+	return &endpoints.Endpoint{
+		BaseURL:     c.BaseURL,
+		Version:     c.APIVersion,
+		BasePath:    basePath,
+		Path:        path,
+		AccountHash: c.AccountHash,
+	}
+}
+
+// HTTPClient returns the dedicated http.Client used for requests
+func (c *Client) HTTPClient() *http.Client {
+	return c.c
+}
+
+// roundTrip sends a single attempt once the rate limiter allows it
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return c.c.Do(req)
+}
+
 // GetHeaders Generates the minimum required headers
 func (c *Client) GetHeaders() []*Header {
 	var headers []*Header
@@ -155,5 +337,18 @@ func (c *Client) GetHeaders() []*Header {
 	}
 	headers = append(headers, appID)
 
+	headers = append(headers, &Header{
+		Key:   "User-Agent",
+		Value: c.UserAgent(),
+	})
+
 	return headers
 }
+
+// UserAgent returns the User-Agent sent with every request
+func (c *Client) UserAgent() string {
+	if c.userAgent == "" {
+		return UserAgent(c.ApplicationID, "")
+	}
+	return c.userAgent
+}
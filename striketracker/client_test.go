@@ -2,7 +2,11 @@ package striketracker
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
+
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
 )
 
 const (
@@ -52,8 +56,8 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("Expected ApplicationID to be %s but found %s", TestID, c.ApplicationID)
 	}
 
-	if c.Identity.AuthorizationHeaderToken != TestToken {
-		t.Errorf("Expected AuthorizationHeaderToken to be %s but found %s", TestToken, c.Identity.AuthorizationHeaderToken)
+	if c.Identity.Token() != TestToken {
+		t.Errorf("Expected AuthorizationHeaderToken to be %s but found %s", TestToken, c.Identity.Token())
 	}
 
 	expectedBearer := fmt.Sprintf("Bearer %s", TestToken)
@@ -63,3 +67,97 @@ func TestNewClient(t *testing.T) {
 	}
 
 }
+
+func TestNewClientDedicatedHTTPClient(t *testing.T) {
+	defaultTimeout := http.DefaultClient.Timeout
+
+	c, err := NewClient(&Configuration{
+		ApplicationID:            TestID,
+		AuthorizationHeaderToken: TestToken,
+		Timeout:                  3,
+	})
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if c.HTTPClient() == http.DefaultClient {
+		t.Fatalf("Expected a dedicated http.Client but found http.DefaultClient")
+	}
+
+	if http.DefaultClient.Timeout != defaultTimeout {
+		t.Fatalf("Expected http.DefaultClient timeout to be untouched but it is now %v", http.DefaultClient.Timeout)
+	}
+
+	if c.HTTPClient().Timeout != 3*time.Second {
+		t.Fatalf("Expected timeout of 3s but got %v", c.HTTPClient().Timeout)
+	}
+
+	if _, ok := c.HTTPClient().Transport.(*http.Transport); !ok {
+		t.Fatalf("Expected a pooled *http.Transport but got %T", c.HTTPClient().Transport)
+	}
+}
+
+func TestNewClientInjectedHTTPClient(t *testing.T) {
+	injected := &http.Client{Timeout: 42 * time.Second}
+	transport := &http.Transport{}
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithHTTPClient(injected),
+		WithTransport(transport),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if c.HTTPClient() == injected {
+		t.Fatalf("Expected injected client to be copied, not shared")
+	}
+
+	if injected.Transport != nil {
+		t.Fatalf("Expected injected client to be left unmodified")
+	}
+
+	if c.HTTPClient().Timeout != 42*time.Second {
+		t.Fatalf("Expected injected timeout to be kept but got %v", c.HTTPClient().Timeout)
+	}
+
+	if c.HTTPClient().Transport != transport {
+		t.Fatalf("Expected injected transport to be used")
+	}
+}
+
+func TestNewClientBaseURL(t *testing.T) {
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if found := c.NewEndpoint(endpoints.Origins, "/origins").Format("abc123"); found != "https://striketracker.highwinds.com/api/v1/accounts/abc123/origins" {
+		t.Fatalf("Expected production endpoint by default but got %s", found)
+	}
+
+	c, err = NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithBaseURL("http://127.0.0.1:8080/"),
+		WithAPIVersion("/api/v2"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if found := c.NewEndpoint(endpoints.Origins, "/origins").Format("abc123"); found != "http://127.0.0.1:8080/api/v2/accounts/abc123/origins" {
+		t.Fatalf("Expected configured base URL to flow into endpoint but got %s", found)
+	}
+
+	_, err = NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithBaseURL("not a url"),
+	)
+	if err == nil {
+		t.Fatalf("Expected invalid base URL to fail validation")
+	}
+}
@@ -0,0 +1,112 @@
+package striketracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentRotation rotates the token and default headers while
+// requests are in flight, run with -race to detect unsynchronized access
+func TestConcurrentRotation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken("token-0"),
+		WithAccountHash("parent"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	view := c.ForAccount("child")
+
+	const rotations = 50
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Rotator
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 1; i <= rotations; i++ {
+			c.SetHeader("X-Rotation", fmt.Sprint(i))
+			c.SetToken(fmt.Sprintf("token-%d", i))
+			c.Headers()
+		}
+		c.DelHeader("X-Rotation")
+	}()
+
+	// Requesters on the client and a view
+	errs := make(chan error, 8)
+	for _, client := range []*Client{c, view, c, view} {
+		wg.Add(1)
+		go func(client *Client) {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := client.Call(context.Background(), GET, server.URL, nil, nil, nil); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(client)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Expected requests to succeed during rotation but got: %v", err)
+	}
+
+	if c.Identity.Token() != fmt.Sprintf("token-%d", rotations) {
+		t.Fatalf("Expected final token to be token-%d but got %s", rotations, c.Identity.Token())
+	}
+
+	if view.Identity.Token() != c.Identity.Token() {
+		t.Fatalf("Expected rotation to be shared with account views")
+	}
+
+	for _, header := range view.Headers() {
+		if header.Key == "X-Rotation" {
+			t.Fatalf("Expected deleted header to be removed from account views")
+		}
+	}
+}
+
+func TestSetHeader(t *testing.T) {
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	c.SetHeader("x-application-id", "Rotated")
+	c.SetHeader("X-Custom", "1")
+
+	headers := c.Headers()
+	if len(headers) != 3 || headers[0].Value != "Rotated" || headers[1].Key != "User-Agent" || headers[2].Key != "X-Custom" {
+		t.Fatalf("Expected application ID to be replaced and custom header appended but got %v", headers)
+	}
+
+	// Snapshots cannot mutate the client
+	headers[0].Value = "Mutated"
+	if c.Headers()[0].Value != "Rotated" {
+		t.Fatalf("Expected Headers to return a copy")
+	}
+}
@@ -1,21 +1,76 @@
 package striketracker
 
 import (
+	"crypto/tls"
+	"net/http"
+
 	"github.com/openwurl/wurlwind/pkg/validation"
+	"github.com/openwurl/wurlwind/striketracker/identity"
 	"gopkg.in/go-playground/validator.v9"
 )
 
 // Configuration provides a service configuration for the client
 type Configuration struct {
-	Debug                    bool   `json:"debug"`
-	AuthorizationHeaderToken string `json:"authorizationHeaderToken" validate:"required"`
-	ApplicationID            string `json:"applicationID" validate:"required"`
-	Timeout                  int    `json:"timeout"`
+	Debug                    bool    `json:"debug"`
+	AuthorizationHeaderToken string  `json:"authorizationHeaderToken" validate:"required_without_all=Username TokenSource"`
+	ApplicationID            string  `json:"applicationID" validate:"required"`
+	Timeout                  int     `json:"timeout"`
+	BaseURL                  string  `json:"baseURL" validate:"omitempty,url"`
+	APIVersion               string  `json:"apiVersion"`
+	RateLimit                float64 `json:"rateLimit" validate:"gte=0"`
+	RateBurst                int     `json:"rateBurst" validate:"gte=0"`
+	Username                 string  `json:"username" validate:"required_with=Password"`
+	Password                 string  `json:"password" validate:"required_with=Username"`
+	AccountHash              string  `json:"accountHash"`
+	DryRun                   bool    `json:"dryRun"`
+	UserAgentSuffix          string  `json:"userAgentSuffix"`
+	CacheTTL                 int     `json:"cacheTTL" validate:"gte=0"`
+	StrictDecoding           bool    `json:"strictDecoding"`
+
+	// ProxyURL routes requests through an outbound proxy, defaults to the
+	// HTTP_PROXY and HTTPS_PROXY environment variables
+	ProxyURL string `json:"proxyURL" validate:"omitempty,url"`
+	// RootCAFiles are PEM bundles trusted in addition to the system roots
+	RootCAFiles []string `json:"rootCAFiles"`
+	// ClientCertFile and ClientKeyFile are a PEM key pair for mutual TLS
+	ClientCertFile string `json:"clientCertFile" validate:"required_with=ClientKeyFile"`
+	ClientKeyFile  string `json:"clientKeyFile" validate:"required_with=ClientCertFile"`
+
+	// ConfigFile and Profile select a credentials file profile to load
+	ConfigFile string `json:"-"`
+	Profile    string `json:"-"`
+	// CredentialProviders are tried in order when no token is given
+	CredentialProviders []CredentialProvider `json:"-"`
+	// TokenSource supplies bearer tokens in place of AuthorizationHeaderToken
+	TokenSource identity.TokenSource `json:"-"`
+	// HTTPClient is copied and used for all requests if defined
+	HTTPClient *http.Client `json:"-"`
+	// Transport overrides the transport of the dedicated http.Client
+	Transport http.RoundTripper `json:"-"`
+	// RootCAs are PEM bundles trusted in addition to the system roots
+	RootCAs [][]byte `json:"-"`
+	// ClientCertificates are presented for mutual TLS
+	ClientCertificates []tls.Certificate `json:"-"`
+	// Logger receives debug records, defaults to stderr when Debug is set
+	Logger Logger `json:"-"`
+	// Middleware wraps every request in order, the first being outermost
+	Middleware []Middleware `json:"-"`
+	// RetryPolicy enables automatic retries of transient failures
+	RetryPolicy *RetryPolicy `json:"-"`
+	// UnknownFieldsHandler receives response fields missing from the models
+	UnknownFieldsHandler UnknownFieldsHandler `json:"-"`
+
+	// options built the configuration and are re-applied over a profile
+	options []func(*Configuration)
 }
 
 // NewConfiguration creates a new Configuration with the provided options.
+//
+// The options are remembered so they take precedence over a profile
+// loaded by NewClientFromConfiguration, including those turning a
+// setting off such as WithDebug(false).
 func NewConfiguration(options ...func(*Configuration)) (*Configuration, error) {
-	config := Configuration{}
+	config := Configuration{options: options}
 
 	for _, option := range options {
 		option(&config)
@@ -45,6 +100,15 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithLogger directs debug records to the given Logger
+// Records are only emitted when Debug is enabled
+// Default is the standard library logger on stderr
+func WithLogger(logger Logger) Option {
+	return func(c *Configuration) {
+		c.Logger = logger
+	}
+}
+
 // WithAuthorizationHeaderToken adds an auth token on instantiation
 func WithAuthorizationHeaderToken(token string) Option {
 	return func(c *Configuration) {
@@ -52,6 +116,44 @@ func WithAuthorizationHeaderToken(token string) Option {
 	}
 }
 
+// WithCredentialProviders looks up the authorization token from each
+// provider in order when no token is given explicitly
+//
+//  striketracker.WithCredentialProviders(
+//  	striketracker.EnvCredentials("AUTHORIZATIONHEADERKEY"),
+//  	striketracker.FileCredentials("/run/secrets/striketracker"),
+//  	striketracker.CommandCredentials("pass", "show", "striketracker"),
+//  )
+func WithCredentialProviders(providers ...CredentialProvider) Option {
+	return func(c *Configuration) {
+		c.CredentialProviders = append(c.CredentialProviders, providers...)
+	}
+}
+
+// WithPasswordCredentials authenticates with a username and password through
+// POST /auth/token instead of a permanent token, refreshing the access
+// token automatically before it expires
+func WithPasswordCredentials(username string, password string) Option {
+	return func(c *Configuration) {
+		c.Username = username
+		c.Password = password
+	}
+}
+
+// WithTokenSource supplies bearer tokens from a custom identity.TokenSource
+func WithTokenSource(source identity.TokenSource) Option {
+	return func(c *Configuration) {
+		c.TokenSource = source
+	}
+}
+
+// WithAccountHash sets the default account hash
+func WithAccountHash(accountHash string) Option {
+	return func(c *Configuration) {
+		c.AccountHash = accountHash
+	}
+}
+
 // WithApplicationID adds the ApplicationID on instantiation
 func WithApplicationID(appID string) Option {
 	return func(c *Configuration) {
@@ -59,6 +161,14 @@ func WithApplicationID(appID string) Option {
 	}
 }
 
+// WithUserAgentSuffix appends a product token such as "deploy-bot/1.2.0"
+// to the User-Agent sent with every request
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Configuration) {
+		c.UserAgentSuffix = suffix
+	}
+}
+
 // WithRequestTimeout adds a timeout for outgoing requests
 // Default is 10s
 func WithRequestTimeout(timeout int) Option {
@@ -67,11 +177,159 @@ func WithRequestTimeout(timeout int) Option {
 	}
 }
 
-/* Not Implemented yet
-// WithConfigFile loads configuration from a configuration file
-func WithConfigFile(filepath string) Config {
+// WithBaseURL points every service at an alternate Striketracker host
+// such as staging, a recording proxy, or an httptest server
+// Default is https://striketracker.highwinds.com
+func WithBaseURL(baseURL string) Option {
+	return func(c *Configuration) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithAPIVersion overrides the API version path
+// Default is /api/v1
+func WithAPIVersion(version string) Option {
+	return func(c *Configuration) {
+		c.APIVersion = version
+	}
+}
+
+// WithHTTPClient supplies an http.Client to base the dedicated client on
+// The supplied client is copied and never modified
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Configuration) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport supplies the RoundTripper used for outgoing requests
+// Default is a pooled transport from NewTransport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Configuration) {
+		c.Transport = transport
+	}
+}
+
+// WithProxyURL routes requests through an outbound proxy such as
+// http://proxy.example.com:3128
+// Default is the HTTP_PROXY and HTTPS_PROXY environment variables
+func WithProxyURL(proxyURL string) Option {
+	return func(c *Configuration) {
+		c.ProxyURL = proxyURL
+	}
+}
+
+// WithRootCAFiles trusts the certificates in the PEM bundles at each path
+// in addition to the system roots, such as an inspecting proxy's CA
+func WithRootCAFiles(paths ...string) Option {
 	return func(c *Configuration) {
+		c.RootCAFiles = append(c.RootCAFiles, paths...)
 	}
 }
 
-*/
+// WithRootCAs trusts the certificates in each PEM bundle in addition to the system roots
+func WithRootCAs(bundles ...[]byte) Option {
+	return func(c *Configuration) {
+		c.RootCAs = append(c.RootCAs, bundles...)
+	}
+}
+
+// WithClientCertificateFiles presents the PEM encoded certificate and key for mutual TLS
+func WithClientCertificateFiles(certFile string, keyFile string) Option {
+	return func(c *Configuration) {
+		c.ClientCertFile = certFile
+		c.ClientKeyFile = keyFile
+	}
+}
+
+// WithClientCertificate presents the certificate for mutual TLS
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *Configuration) {
+		c.ClientCertificates = append(c.ClientCertificates, cert)
+	}
+}
+
+// WithRetryPolicy enables automatic retry with backoff for rate limiting
+// and transient Striketracker errors
+// Default is a single attempt
+//
+//  striketracker.WithRetryPolicy(striketracker.DefaultRetryPolicy())
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Configuration) {
+		c.RetryPolicy = policy
+	}
+}
+
+// WithRateLimit throttles outgoing requests to requestsPerSecond with
+// bursts of up to burst requests, shared by every service using the client
+// Default is unlimited
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Configuration) {
+		c.RateLimit = requestsPerSecond
+		c.RateBurst = burst
+	}
+}
+
+// WithResponseCache caches successful GET responses for ttl seconds,
+// keyed by URL and account, and invalidates them after mutating calls
+// on the same path
+// Default is no caching
+func WithResponseCache(ttl int) Option {
+	return func(c *Configuration) {
+		c.CacheTTL = ttl
+	}
+}
+
+// WithStrictDecoding fails GET and HEAD calls whose responses contain
+// fields missing from the model decoded into with an *UnknownFieldsError
+//
+// Mutations still succeed, so created resources are not lost, and their
+// unknown fields are reported to the UnknownFieldsHandler or the logger
+// Default is false, unknown fields are silently dropped
+func WithStrictDecoding(strict bool) Option {
+	return func(c *Configuration) {
+		c.StrictDecoding = strict
+	}
+}
+
+// WithUnknownFieldsHandler reports response fields missing from the model
+// decoded into without failing the call, such as with LogUnknownFields
+func WithUnknownFieldsHandler(handler UnknownFieldsHandler) Option {
+	return func(c *Configuration) {
+		c.UnknownFieldsHandler = handler
+	}
+}
+
+// WithDryRun records POST, PUT, PATCH and DELETE requests in place of
+// sending them, returning a synthetic result, GET requests are still sent
+// Default is false
+func WithDryRun(dryRun bool) Option {
+	return func(c *Configuration) {
+		c.DryRun = dryRun
+	}
+}
+
+// WithMiddleware appends middleware wrapping every request made by the
+// client, the first middleware given is the outermost
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Configuration) {
+		c.Middleware = append(c.Middleware, middleware...)
+	}
+}
+
+// WithConfigFile loads configuration from a JSON credentials file
+// Options given explicitly take precedence over the file
+// Default is ~/.striketracker/config.json when a profile is selected
+func WithConfigFile(filepath string) Option {
+	return func(c *Configuration) {
+		c.ConfigFile = filepath
+	}
+}
+
+// WithProfile selects a named profile from the credentials file
+// Default is the STRIKETRACKER_PROFILE environment variable, then "default"
+func WithProfile(profile string) Option {
+	return func(c *Configuration) {
+		c.Profile = profile
+	}
+}
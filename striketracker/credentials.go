@@ -0,0 +1,223 @@
+package striketracker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/openwurl/wurlwind/pkg/fileio"
+)
+
+// CredentialProvider supplies the authorization header token
+type CredentialProvider interface {
+	// Name describes the source for error messages
+	Name() string
+	// Retrieve returns the token or an error describing why it is unavailable
+	Retrieve() (string, error)
+}
+
+// CredentialChainError lists every source tried when no token was found
+type CredentialChainError struct {
+	Tried  []string
+	Errors []error
+}
+
+// Error describes each source tried and why it failed
+func (e *CredentialChainError) Error() string {
+	if len(e.Tried) == 0 {
+		return "No authorization token found, no credential providers configured"
+	}
+	reasons := make([]string, len(e.Tried))
+	for i := range e.Tried {
+		reasons[i] = fmt.Sprintf("%s (%v)", e.Tried[i], e.Errors[i])
+	}
+	return fmt.Sprintf("No authorization token found, tried: %s", strings.Join(reasons, ", "))
+}
+
+// ResolveCredentials walks the providers in order returning the first token found
+func ResolveCredentials(providers ...CredentialProvider) (string, error) {
+	chainErr := &CredentialChainError{}
+	for _, provider := range providers {
+		token, err := provider.Retrieve()
+		if err == nil && token != "" {
+			return token, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("empty token")
+		}
+		chainErr.Tried = append(chainErr.Tried, provider.Name())
+		chainErr.Errors = append(chainErr.Errors, err)
+	}
+	return "", chainErr
+}
+
+// DefaultCredentialChain checks the AUTHORIZATIONHEADERKEY environment
+// variable and then the selected credentials file profile
+func DefaultCredentialChain() []CredentialProvider {
+	return []CredentialProvider{
+		EnvCredentials("AUTHORIZATIONHEADERKEY"),
+		ProfileCredentials("", ""),
+	}
+}
+
+// staticCredentials is a literal token
+type staticCredentials string
+
+// StaticCredentials returns a provider for a literal token
+func StaticCredentials(token string) CredentialProvider {
+	return staticCredentials(token)
+}
+
+func (s staticCredentials) Name() string {
+	return "static token"
+}
+
+func (s staticCredentials) Retrieve() (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("not set")
+	}
+	return string(s), nil
+}
+
+// envCredentials reads an environment variable
+type envCredentials string
+
+// EnvCredentials returns a provider reading the named environment variable
+func EnvCredentials(name string) CredentialProvider {
+	return envCredentials(name)
+}
+
+func (e envCredentials) Name() string {
+	return fmt.Sprintf("environment variable %s", string(e))
+}
+
+func (e envCredentials) Retrieve() (string, error) {
+	token := os.Getenv(string(e))
+	if token == "" {
+		return "", fmt.Errorf("not set")
+	}
+	return token, nil
+}
+
+// fileCredentials reads a file containing only the token
+type fileCredentials string
+
+// FileCredentials returns a provider reading the token from a file,
+// surrounding whitespace is ignored
+func FileCredentials(path string) CredentialProvider {
+	return fileCredentials(path)
+}
+
+func (f fileCredentials) Name() string {
+	return fmt.Sprintf("file %s", string(f))
+}
+
+func (f fileCredentials) Retrieve() (string, error) {
+	contents, err := fileio.FileToString(string(f))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(contents), nil
+}
+
+// profileCredentials reads the token of a credentials file profile
+type profileCredentials struct {
+	path    string
+	profile string
+}
+
+// ProfileCredentials returns a provider reading the token from a credentials
+// file profile, empty values select the file and profile as LoadConfigurationFile does
+func ProfileCredentials(path string, profile string) CredentialProvider {
+	return &profileCredentials{path: path, profile: profile}
+}
+
+func (p *profileCredentials) Name() string {
+	path, profile := p.path, p.profile
+	if path == "" {
+		path = "default file"
+	}
+	if profile == "" {
+		profile = "selected"
+	}
+	return fmt.Sprintf("%s profile in %s", profile, path)
+}
+
+func (p *profileCredentials) Retrieve() (string, error) {
+	config, err := LoadConfigurationFile(p.path, p.profile)
+	if err != nil {
+		return "", err
+	}
+	return config.AuthorizationHeaderToken, nil
+}
+
+// DefaultCommandTimeout bounds how long CommandCredentials waits for the command
+const DefaultCommandTimeout = 10 * time.Second
+
+// commandCredentials runs an external command such as a secrets manager CLI
+type commandCredentials struct {
+	name    string
+	args    []string
+	timeout time.Duration
+}
+
+// CommandCredentials returns a provider using the trimmed standard output
+// of an external command, which is killed after DefaultCommandTimeout
+//
+//  striketracker.CommandCredentials("vault", "kv", "get", "-field=token", "secret/striketracker")
+func CommandCredentials(name string, args ...string) CredentialProvider {
+	return CommandCredentialsTimeout(DefaultCommandTimeout, name, args...)
+}
+
+// CommandCredentialsTimeout returns a CommandCredentials provider which
+// kills the command after timeout, such as a helper waiting for an unlock
+func CommandCredentialsTimeout(timeout time.Duration, name string, args ...string) CredentialProvider {
+	return &commandCredentials{name: name, args: args, timeout: timeout}
+}
+
+func (c *commandCredentials) Name() string {
+	return fmt.Sprintf("command %s", c.name)
+}
+
+// Retrieve runs the command, errors include anything it wrote to stderr
+func (c *commandCredentials) Retrieve() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %v", c.timeout)
+	} else if err == nil && len(bytes.TrimSpace(out)) == 0 {
+		err = fmt.Errorf("empty token")
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// resolveCredentials fills the token from the credential providers when
+// no other form of authorization was configured
+func (c *Configuration) resolveCredentials() error {
+	if c.AuthorizationHeaderToken != "" || c.Username != "" || c.TokenSource != nil || len(c.CredentialProviders) == 0 {
+		return nil
+	}
+
+	token, err := ResolveCredentials(c.CredentialProviders...)
+	if err != nil {
+		return err
+	}
+	c.AuthorizationHeaderToken = token
+	return nil
+}
@@ -0,0 +1,133 @@
+package striketracker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveCredentialsOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "striketracker")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(tokenFile, []byte("filetoken\n"), 0600); err != nil {
+		t.Fatalf("Expected to write token file but got: %v", err)
+	}
+
+	os.Setenv("WURLWIND_TEST_TOKEN", "envtoken")
+	defer os.Unsetenv("WURLWIND_TEST_TOKEN")
+
+	var testSuite = []struct {
+		name      string
+		providers []CredentialProvider
+		expected  string
+	}{
+		{
+			name:      "static first",
+			providers: []CredentialProvider{StaticCredentials("statictoken"), EnvCredentials("WURLWIND_TEST_TOKEN")},
+			expected:  "statictoken",
+		},
+		{
+			name:      "skips unset environment",
+			providers: []CredentialProvider{EnvCredentials("WURLWIND_TEST_UNSET"), EnvCredentials("WURLWIND_TEST_TOKEN")},
+			expected:  "envtoken",
+		},
+		{
+			name:      "skips missing file",
+			providers: []CredentialProvider{FileCredentials(filepath.Join(dir, "missing")), FileCredentials(tokenFile)},
+			expected:  "filetoken",
+		},
+		{
+			name:      "command output",
+			providers: []CredentialProvider{StaticCredentials(""), CommandCredentials("echo", "commandtoken")},
+			expected:  "commandtoken",
+		},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := ResolveCredentials(tt.providers...)
+			if err != nil {
+				t.Fatalf("Expected a token but got: %v", err)
+			}
+			if token != tt.expected {
+				t.Fatalf("Expected token %s but got %s", tt.expected, token)
+			}
+		})
+	}
+}
+
+func TestResolveCredentialsDescribesSources(t *testing.T) {
+	_, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithCredentialProviders(
+			EnvCredentials("WURLWIND_TEST_UNSET"),
+			FileCredentials("/nonexistent/wurlwind/token"),
+			CommandCredentials("false"),
+		),
+	)
+	if err == nil {
+		t.Fatalf("Expected an error when no provider has a token")
+	}
+
+	chainErr, ok := err.(*CredentialChainError)
+	if !ok {
+		t.Fatalf("Expected *CredentialChainError but got %T: %v", err, err)
+	}
+
+	if len(chainErr.Tried) != 3 {
+		t.Fatalf("Expected 3 sources to be tried but got %v", chainErr.Tried)
+	}
+
+	for _, source := range []string{"environment variable WURLWIND_TEST_UNSET", "file /nonexistent/wurlwind/token", "command false"} {
+		if !strings.Contains(err.Error(), source) {
+			t.Fatalf("Expected error to describe %s but got: %v", source, err)
+		}
+	}
+}
+
+func TestCredentialProvidersDoNotOverrideToken(t *testing.T) {
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithCredentialProviders(StaticCredentials("othertoken")),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if c.Identity.Token() != TestToken {
+		t.Fatalf("Expected explicit token to win over providers but got %s", c.Identity.Token())
+	}
+}
+
+func TestCommandCredentialsTimeout(t *testing.T) {
+	start := time.Now()
+	_, err := CommandCredentialsTimeout(200*time.Millisecond, "sh", "-c", "echo locked >&2; exec sleep 5").Retrieve()
+	if err == nil {
+		t.Fatalf("Expected a hung command to fail")
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Fatalf("Expected the command to be killed after its timeout")
+	}
+	if !strings.Contains(err.Error(), "timed out") || !strings.Contains(err.Error(), "locked") {
+		t.Fatalf("Expected timeout error with stderr but got: %v", err)
+	}
+}
+
+func TestCommandCredentialsStderr(t *testing.T) {
+	_, err := ResolveCredentials(CommandCredentials("sh", "-c", "echo vault is sealed >&2"))
+	if _, ok := err.(*CredentialChainError); !ok {
+		t.Fatalf("Expected *CredentialChainError but got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "vault is sealed") {
+		t.Fatalf("Expected error to include the command's stderr but got: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package striketracker
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// DryRunHeader is set on synthetic responses produced in dry-run mode
+const DryRunHeader = "X-Wurlwind-Dry-Run"
+
+// DryRunRecord describes a mutating request which was not sent
+type DryRunRecord struct {
+	Method string
+	URL    string
+	Header http.Header // Authorization is redacted
+	Body   []byte      // Sensitive fields such as certificate keys are redacted
+}
+
+// dryRunRecorder collects records for a client and its views
+type dryRunRecorder struct {
+	mu      sync.Mutex
+	records []DryRunRecord
+}
+
+type dryRunBypassKey struct{}
+
+// withoutDryRun marks a request to always be sent, such as logging in
+func withoutDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunBypassKey{}, true)
+}
+
+// isMutating reports whether the method changes state on Striketracker
+func isMutating(method string) bool {
+	switch HTTPMethod(method) {
+	case POST, PUT, PATCH, DELETE:
+		return true
+	}
+	return false
+}
+
+// dryRun records a mutating request and returns a synthetic response in
+// place of sending it, or nil if the request should be sent
+//
+// POST, PUT and PATCH echo the request body so services return the model
+// they would have sent, DELETE returns an empty 204
+func (c *Client) dryRun(req *http.Request) (*http.Response, error) {
+	if c.dryRunRecorder == nil || !isMutating(req.Method) {
+		return nil, nil
+	}
+	if bypass, _ := req.Context().Value(dryRunBypassKey{}).(bool); bypass {
+		return nil, nil
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		if body, err = ioutil.ReadAll(rc); err != nil {
+			return nil, err
+		}
+	}
+
+	c.dryRunRecorder.mu.Lock()
+	c.dryRunRecorder.records = append(c.dryRunRecorder.records, DryRunRecord{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: redactHeaders(req.Header),
+		Body:   redactBody(req.Header.Get("Content-Type"), body),
+	})
+	c.dryRunRecorder.mu.Unlock()
+
+	resp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+	if req.Method == DELETE.String() || len(body) == 0 {
+		resp.Status = "204 No Content"
+		resp.StatusCode = http.StatusNoContent
+		resp.Body = ioutil.NopCloser(bytes.NewReader(nil))
+	} else {
+		resp.Header.Set("Content-Type", "application/json")
+	}
+	resp.Header.Set(DryRunHeader, "true")
+
+	return resp, nil
+}
+
+// DryRun reports whether the client is in dry-run mode
+func (c *Client) DryRun() bool {
+	return c.dryRunRecorder != nil
+}
+
+// DryRunRecords returns the mutating requests which would have been sent
+func (c *Client) DryRunRecords() []DryRunRecord {
+	if c.dryRunRecorder == nil {
+		return nil
+	}
+
+	c.dryRunRecorder.mu.Lock()
+	defer c.dryRunRecorder.mu.Unlock()
+
+	out := make([]DryRunRecord, len(c.dryRunRecorder.records))
+	copy(out, c.dryRunRecorder.records)
+	return out
+}
+
+// ResetDryRunRecords discards the recorded requests
+func (c *Client) ResetDryRunRecords() {
+	if c.dryRunRecorder == nil {
+		return
+	}
+
+	c.dryRunRecorder.mu.Lock()
+	defer c.dryRunRecorder.mu.Unlock()
+	c.dryRunRecorder.records = nil
+}
@@ -0,0 +1,98 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	var sent int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&sent, 1)
+		w.Write([]byte(`{"name":"existing"}`))
+	}))
+	defer server.Close()
+
+	config := *BaseConfiguration
+	config.DryRun = true
+	c, err := NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	out := &validatedModel{}
+	resp, err := c.Call(context.Background(), POST, server.URL+"/origins", nil, &validatedModel{Name: "new"}, out)
+	if err != nil {
+		t.Fatalf("Expected dry-run create to succeed but got: %v", err)
+	}
+	if out.Name != "new" {
+		t.Fatalf("Expected synthetic result to echo the input but got %v", out)
+	}
+	if resp.Header.Get(DryRunHeader) != "true" {
+		t.Fatalf("Expected synthetic response to carry %s", DryRunHeader)
+	}
+
+	if _, err = c.Call(context.Background(), DELETE, server.URL+"/origins/1", nil, nil, nil); err != nil {
+		t.Fatalf("Expected dry-run delete to succeed but got: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&sent); n != 0 {
+		t.Fatalf("Expected no mutating requests to be sent but server saw %d", n)
+	}
+
+	if _, err = c.Call(context.Background(), GET, server.URL+"/origins/1", nil, nil, out); err != nil {
+		t.Fatalf("Expected read to succeed but got: %v", err)
+	}
+	if n := atomic.LoadInt32(&sent); n != 1 || out.Name != "existing" {
+		t.Fatalf("Expected reads to be sent in dry-run mode but server saw %d, decoded %v", n, out)
+	}
+
+	records := c.DryRunRecords()
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 dry-run records but got %d", len(records))
+	}
+	if records[0].Method != "POST" || records[0].URL != server.URL+"/origins" || strings.TrimSpace(string(records[0].Body)) != `{"name":"new"}` {
+		t.Fatalf("Expected create to be recorded but got %+v", records[0])
+	}
+	if records[0].Header.Get("Authorization") != "Bearer "+Redacted {
+		t.Fatalf("Expected recorded authorization to be redacted but got %s", records[0].Header.Get("Authorization"))
+	}
+	if records[1].Method != "DELETE" || records[1].URL != server.URL+"/origins/1" {
+		t.Fatalf("Expected delete to be recorded but got %+v", records[1])
+	}
+
+	c.ResetDryRunRecords()
+	if len(c.DryRunRecords()) != 0 {
+		t.Fatalf("Expected records to be discarded")
+	}
+}
+
+func TestDryRunRecordsRedactBodies(t *testing.T) {
+	config := *BaseConfiguration
+	config.DryRun = true
+	c, err := NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	in := map[string]string{"certificate": "PUBLIC", "key": "PRIVATE"}
+	out := map[string]string{}
+	if _, err = c.Call(context.Background(), POST, "http://example.com/certificates", nil, in, &out); err != nil {
+		t.Fatalf("Expected dry-run upload to succeed but got: %v", err)
+	}
+	if out["key"] != "PRIVATE" {
+		t.Fatalf("Expected the synthetic result to echo the model but got %v", out)
+	}
+
+	records := c.DryRunRecords()
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 dry-run record but got %d", len(records))
+	}
+	if strings.Contains(string(records[0].Body), "PRIVATE") || !strings.Contains(string(records[0].Body), Redacted) {
+		t.Fatalf("Expected the private key to be redacted but got %s", records[0].Body)
+	}
+}
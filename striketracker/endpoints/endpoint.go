@@ -1,26 +1,78 @@
 package endpoints
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 // Endpoint describes a base API endpoint at striketracker
+//
+// BaseURL and Version default to URL and V1 when empty, which allows
+// pointing services at staging, a recording proxy or an httptest server
+//
+// AccountHash is the default account used when an empty account hash
+// is given to any of the formatting methods
 type Endpoint struct {
-	BasePath BasePath
-	Path     string
+	BaseURL     string
+	Version     string
+	BasePath    BasePath
+	Path        string
+	AccountHash string
+}
+
+// MissingAccountHash stands in for the account hash of URLs formatted
+// without one and without a default account, so they can be refused
+// before being sent
+const MissingAccountHash = "{account_hash}"
+
+// Account returns accountHash, or the default account if it is empty,
+// or MissingAccountHash if neither is set
+func (e *Endpoint) Account(accountHash string) string {
+	if accountHash == "" {
+		accountHash = e.AccountHash
+	}
+	if accountHash == "" {
+		return MissingAccountHash
+	}
+	return accountHash
+}
+
+// MissingAccount reports whether URL was formatted by an Endpoint without
+// an account hash
+func MissingAccount(URL string) bool {
+	return strings.Contains(URL, "/"+MissingAccountHash)
+}
+
+// Root returns the base URL without API version
+func (e *Endpoint) Root() string {
+	if e.BaseURL == "" {
+		return URL
+	}
+	return strings.TrimSuffix(e.BaseURL, "/")
+}
+
+// APIVersion returns the API version path
+func (e *Endpoint) APIVersion() string {
+	if e.Version == "" {
+		return V1
+	}
+	return e.Version
 }
 
 // String returns the base path up until account hash
 func (e *Endpoint) String() string {
-	return fmt.Sprintf("%s%s%s", URL, V1, e.BasePath)
+	return fmt.Sprintf("%s%s%s", e.Root(), e.APIVersion(), e.BasePath)
 }
 
 // FormatAccountHash returns the base path up until the account hash
 func (e *Endpoint) FormatAccountHash(accountHash string) string {
-	return fmt.Sprintf("%s/%s", e.String(), accountHash)
+	return fmt.Sprintf("%s/%s", e.String(), e.Account(accountHash))
 }
 
 // Format returns a formatted URL with account hash and path
 func (e *Endpoint) Format(accountHash string) string {
-	return fmt.Sprintf("%s/%s%s", e.String(), accountHash, e.Path)
+	return fmt.Sprintf("%s/%s%s", e.String(), e.Account(accountHash), e.Path)
 }
 
 // CustomFormat appends strings in order after account hash
@@ -32,3 +84,15 @@ func (e *Endpoint) CustomFormat(accountHash string, fields ...string) string {
 	}
 	return output
 }
+
+// Segments returns a formatted URL with account hash and path followed by
+// each escaped segment
+//
+//  e.Segments(accountHash, "8675309", "hosts") // .../{account_hash}/certificates/8675309/hosts
+func (e *Endpoint) Segments(accountHash string, segments ...string) string {
+	output := e.Format(accountHash)
+	for _, segment := range segments {
+		output = fmt.Sprintf("%s/%s", output, url.PathEscape(segment))
+	}
+	return output
+}
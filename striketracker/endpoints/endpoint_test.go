@@ -0,0 +1,61 @@
+package endpoints
+
+import "testing"
+
+func TestEndpointFormat(t *testing.T) {
+	var testSuite = []struct {
+		name     string
+		endpoint *Endpoint
+		expected string
+	}{
+		{
+			name:     "production defaults",
+			endpoint: &Endpoint{BasePath: Origins, Path: "/origins"},
+			expected: "https://striketracker.highwinds.com/api/v1/accounts/abc123/origins",
+		},
+		{
+			name:     "custom base URL",
+			endpoint: &Endpoint{BaseURL: "http://127.0.0.1:8080/", BasePath: Origins, Path: "/origins"},
+			expected: "http://127.0.0.1:8080/api/v1/accounts/abc123/origins",
+		},
+		{
+			name:     "custom base URL and version",
+			endpoint: &Endpoint{BaseURL: "https://staging.example.com", Version: "/api/v2", BasePath: Certificates, Path: "/certificates"},
+			expected: "https://staging.example.com/api/v2/accounts/abc123/certificates",
+		},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			if found := tt.endpoint.Format("abc123"); found != tt.expected {
+				t.Fatalf("Expected %s but got %s", tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestEndpointSegments(t *testing.T) {
+	e := &Endpoint{BasePath: Certificates, Path: "/certificates"}
+
+	expected := "https://striketracker.highwinds.com/api/v1/accounts/abc123/certificates/8675309/hosts"
+	if found := e.Segments("abc123", "8675309", "hosts"); found != expected {
+		t.Fatalf("Expected %s but got %s", expected, found)
+	}
+
+	expected = "https://striketracker.highwinds.com/api/v1/accounts/abc123/certificates/a%2Fb"
+	if found := e.Segments("abc123", "a/b"); found != expected {
+		t.Fatalf("Expected segments to be escaped as %s but got %s", expected, found)
+	}
+}
+
+func TestEndpointMissingAccount(t *testing.T) {
+	e := &Endpoint{BasePath: Origins, Path: "/origins"}
+	if found := e.Format(""); !MissingAccount(found) {
+		t.Fatalf("Expected %s to be marked as missing an account", found)
+	}
+
+	e.AccountHash = "default"
+	if found := e.Segments("", "1"); MissingAccount(found) || found != "https://striketracker.highwinds.com/api/v1/accounts/default/origins/1" {
+		t.Fatalf("Expected the default account to be used but got %s", found)
+	}
+}
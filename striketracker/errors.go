@@ -1,63 +1,150 @@
 package striketracker
 
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is an error returned by the Striketracker API
+//
+// StatusCode and URL describe the HTTP exchange while Code and Message
+// carry the error envelope from the response body, if there was one
+type APIError struct {
+	StatusCode int    // HTTP status of the response
+	Code       int    // Striketracker error code
+	Message    string // Striketracker error message
+	URL        string // URL of the failed request
+	Body       []byte // Raw response body for diagnostics
+}
+
+// NewAPIError returns an APIError for the given response and error envelope
+//
+// An empty message indicates the response carried no Striketracker error
+func NewAPIError(resp *http.Response, code int, message string) *APIError {
+	e := &APIError{
+		Code:    code,
+		Message: message,
+	}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		if resp.Request != nil && resp.Request.URL != nil {
+			e.URL = resp.Request.URL.String()
+		}
+	}
+	return e
+}
+
+// Error returns the error in the Striketracker "code: message" format
+//
+// Errors without a Striketracker envelope fall back to the HTTP status
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// ErrorCode returns the Striketracker code, allowing errors such as
+// models.ResponseError to match the sentinels with errors.Is
+func (e *APIError) ErrorCode() int {
+	return e.Code
+}
+
+// Is matches errors by Striketracker code so sentinels can be compared
+// with errors.Is
+//
+// Responses without an envelope, such as those from a gateway, only match
+// the sentinel their HTTP status maps to in statusErrors, so a bare 401
+// matches ErrUnauthenticated and a bare 429 matches ErrLimitExceeded
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if e.Message == "" {
+		mapped, ok := statusErrors[e.StatusCode]
+		return ok && mapped.Code == t.Code
+	}
+	return e.Code == t.Code
+}
+
+// statusErrors maps HTTP statuses of responses without an error envelope
+// to the sentinel with the same meaning, as error codes and statuses are
+// separate namespaces
+var statusErrors = map[int]*APIError{
+	http.StatusUnauthorized:       ErrUnauthenticated,
+	http.StatusForbidden:          ErrPermissionDenied,
+	http.StatusNotFound:           ErrNotFound,
+	http.StatusConflict:           ErrResourceExists,
+	http.StatusGone:               ErrResourceDeleted,
+	http.StatusLocked:             ErrLockedResource,
+	http.StatusTooManyRequests:    ErrLimitExceeded,
+	http.StatusServiceUnavailable: ErrDatabaseDown,
+}
+
 // Possible errors for convenient matching
 //
-//  if err = striketracker.ErrUnhandledFatal {}
-const (
-	ErrUnhandledFatal            = "0: An unhandled fatal error has occurred"
-	ErrGeneric                   = "1: Generic error"
-	ErrMissingRequiredParameter  = "2: A required parameter is missing from a request"
-	ErrInvalidHashCode           = "3: A hash code given is not of a valid format"
-	ErrAccountContextNotFound    = "4: The account request in the account context was not found"
-	ErrInvalidRequestJSON        = "5: The request data is not valid JSON"
-	ErrDisabledFeature           = "6: This feature is temporarily disabled"
-	ErrMissingConfigValue        = "7: An expected configuration value was not set"
-	ErrMissingGroupByParam       = "100: The groupBy parameter on an analytics request is missing or invalid"
-	ErrInvalidGranularity        = "101: An invalid granularity parameter was provided for an analytics request"
-	ErrMissingOrInvalidFilter    = "102: A required filter is missing or a filter contains a bad value"
-	ErrInvalidDateRange          = "103: The date range provided for an analytics request is not valid"
-	ErrInvalidEndDate            = "104: The end date provided is too far in the future (past the end of the current month)"
-	ErrExpiredQuery              = "105: The query results for the requested job ID have expired"
-	ErrUnavailableQuery          = "106: The query results are not yet available (probably because the query is still running). @var int"
-	ErrBulkAnalyticsDisabled     = "107: The account sending the request does not have the Bulk Analytics service enabled."
-	ErrMissingGrantType          = "200: The grant_type parameter for authentication is missing or an invalid value"
-	ErrInvalidAccountContext     = "201: The specified account context is invalid (account is suspended or deleted)"
-	ErrUnauthenticated           = "203: Resource requires authentication but user is not authenticated"
-	ErrPasswordExpired           = "204: Resource requires authentication but the user's password is expired"
-	ErrInvalidConfirmationCode   = "205: Confirmation code is invalid or expired"
-	ErrNotWhitelisted            = "206: The Client IP is not in the Users IP Whitelist"
-	ErrPermissionDenied          = "300: The authenticated user does not have permissions to perform the requested action"
-	ErrUserSuspended             = "301: The authenticated user is suspended"
-	ErrAccountSuspended          = "302: The account is suspended"
-	ErrUnassociatedUser          = "303: The authenticated user does not have an associated account"
-	ErrValidationFailure         = "400: A resource has failed validation"
-	ErrDuplicateOrigin           = "401: A duplicate origin exists with the same hostname, port, and path"
-	ErrNotFound                  = "404: The requested resource was not found"
-	ErrEndpointNotFound          = "405: The requested endpoint was not found, please check your url"
-	ErrResourceExists            = "409: This resource already exists"
-	ErrResourceDeleted           = "410: This resource has been deleted or is expired"
-	ErrWildcardConflict          = "411: There is a conflict with a wildcard hostname"
-	ErrLockedResource            = "423: The requested resource is locked"
-	ErrLimitExceeded             = "429: Your use of this resource exceeds specified rate limit"
-	ErrDatabaseDown              = "503: Unable to reach the database. Please try again later."
-	ErrCDNUnresponsive           = "504: Unable to send configuration to the CDN. Please try again later."
-	ErrApplicationMaintenance    = "505: Application is currently down for maintenance. Please try again later."
-	ErrHCSDown                   = "506: Unable to reach HCS. Please try again later."
-	ErrSOLRDown                  = "507: Unable to reach the SOLR API. Please try again later."
-	ErrAnalyticsDown             = "508: Unable to retrieve current analytics data"
-	ErrAnalyticsTimeout          = "509: Analytics request timed out. Please try again later."
-	ErrAnalyticsResourceNotFound = "510: Unable to find analytics resource. Please try again later."
-	ErrAnalyticsDBDown           = "511: Unable to reach analytics database. Please try again later."
-	ErrHCSValidationFailure      = "600: HCS validation failed"
-	ErrInvalidHCSAuthToken       = "601: HCS Invalid Auth Token"
-	ErrHCSNotEnabled             = "602: HCS Service Not Enabled"
-	ErrMaxTenantsHCS             = "603: HCS Max tenants exceeded"
-	ErrEveryStreamDisabled       = "700: EveryStream Service Not Enabled"
-	ErrEveryStreamNotFound       = "701: EveryStream Account Not Found"
-	ErrEveryStreamSuspended      = "702: EveryStream Account Suspended"
-	ErrEncodingJobStarted        = "703: Encoding Job already in progress"
-	ErrEveryStreamQuota          = "704: EveryStream encoded quota exceeded"
-	ErrTransmuxDisabled          = "705: Transmux Service is not enabled on this account"
-	ErrTransmuxUnprovisioned     = "706: Transmux Service has not been provisioned"
-	ErrTransmuxSuspended         = "707: Transmux Service is suspended"
+//  if errors.Is(err, striketracker.ErrNotFound) {}
+//
+//  var apiErr *striketracker.APIError
+//  if errors.As(err, &apiErr) {
+//  	log.Printf("%s failed with status %d", apiErr.URL, apiErr.StatusCode)
+//  }
+var (
+	ErrUnhandledFatal            = &APIError{Code: 0, Message: "An unhandled fatal error has occurred"}
+	ErrGeneric                   = &APIError{Code: 1, Message: "Generic error"}
+	ErrMissingRequiredParameter  = &APIError{Code: 2, Message: "A required parameter is missing from a request"}
+	ErrInvalidHashCode           = &APIError{Code: 3, Message: "A hash code given is not of a valid format"}
+	ErrAccountContextNotFound    = &APIError{Code: 4, Message: "The account request in the account context was not found"}
+	ErrInvalidRequestJSON        = &APIError{Code: 5, Message: "The request data is not valid JSON"}
+	ErrDisabledFeature           = &APIError{Code: 6, Message: "This feature is temporarily disabled"}
+	ErrMissingConfigValue        = &APIError{Code: 7, Message: "An expected configuration value was not set"}
+	ErrMissingGroupByParam       = &APIError{Code: 100, Message: "The groupBy parameter on an analytics request is missing or invalid"}
+	ErrInvalidGranularity        = &APIError{Code: 101, Message: "An invalid granularity parameter was provided for an analytics request"}
+	ErrMissingOrInvalidFilter    = &APIError{Code: 102, Message: "A required filter is missing or a filter contains a bad value"}
+	ErrInvalidDateRange          = &APIError{Code: 103, Message: "The date range provided for an analytics request is not valid"}
+	ErrInvalidEndDate            = &APIError{Code: 104, Message: "The end date provided is too far in the future (past the end of the current month)"}
+	ErrExpiredQuery              = &APIError{Code: 105, Message: "The query results for the requested job ID have expired"}
+	ErrUnavailableQuery          = &APIError{Code: 106, Message: "The query results are not yet available (probably because the query is still running). @var int"}
+	ErrBulkAnalyticsDisabled     = &APIError{Code: 107, Message: "The account sending the request does not have the Bulk Analytics service enabled."}
+	ErrMissingGrantType          = &APIError{Code: 200, Message: "The grant_type parameter for authentication is missing or an invalid value"}
+	ErrInvalidAccountContext     = &APIError{Code: 201, Message: "The specified account context is invalid (account is suspended or deleted)"}
+	ErrUnauthenticated           = &APIError{Code: 203, Message: "Resource requires authentication but user is not authenticated"}
+	ErrPasswordExpired           = &APIError{Code: 204, Message: "Resource requires authentication but the user's password is expired"}
+	ErrInvalidConfirmationCode   = &APIError{Code: 205, Message: "Confirmation code is invalid or expired"}
+	ErrNotWhitelisted            = &APIError{Code: 206, Message: "The Client IP is not in the Users IP Whitelist"}
+	ErrPermissionDenied          = &APIError{Code: 300, Message: "The authenticated user does not have permissions to perform the requested action"}
+	ErrUserSuspended             = &APIError{Code: 301, Message: "The authenticated user is suspended"}
+	ErrAccountSuspended          = &APIError{Code: 302, Message: "The account is suspended"}
+	ErrUnassociatedUser          = &APIError{Code: 303, Message: "The authenticated user does not have an associated account"}
+	ErrValidationFailure         = &APIError{Code: 400, Message: "A resource has failed validation"}
+	ErrDuplicateOrigin           = &APIError{Code: 401, Message: "A duplicate origin exists with the same hostname, port, and path"}
+	ErrNotFound                  = &APIError{Code: 404, Message: "The requested resource was not found"}
+	ErrEndpointNotFound          = &APIError{Code: 405, Message: "The requested endpoint was not found, please check your url"}
+	ErrResourceExists            = &APIError{Code: 409, Message: "This resource already exists"}
+	ErrResourceDeleted           = &APIError{Code: 410, Message: "This resource has been deleted or is expired"}
+	ErrWildcardConflict          = &APIError{Code: 411, Message: "There is a conflict with a wildcard hostname"}
+	ErrLockedResource            = &APIError{Code: 423, Message: "The requested resource is locked"}
+	ErrLimitExceeded             = &APIError{Code: 429, Message: "Your use of this resource exceeds specified rate limit"}
+	ErrDatabaseDown              = &APIError{Code: 503, Message: "Unable to reach the database. Please try again later."}
+	ErrCDNUnresponsive           = &APIError{Code: 504, Message: "Unable to send configuration to the CDN. Please try again later."}
+	ErrApplicationMaintenance    = &APIError{Code: 505, Message: "Application is currently down for maintenance. Please try again later."}
+	ErrHCSDown                   = &APIError{Code: 506, Message: "Unable to reach HCS. Please try again later."}
+	ErrSOLRDown                  = &APIError{Code: 507, Message: "Unable to reach the SOLR API. Please try again later."}
+	ErrAnalyticsDown             = &APIError{Code: 508, Message: "Unable to retrieve current analytics data"}
+	ErrAnalyticsTimeout          = &APIError{Code: 509, Message: "Analytics request timed out. Please try again later."}
+	ErrAnalyticsResourceNotFound = &APIError{Code: 510, Message: "Unable to find analytics resource. Please try again later."}
+	ErrAnalyticsDBDown           = &APIError{Code: 511, Message: "Unable to reach analytics database. Please try again later."}
+	ErrHCSValidationFailure      = &APIError{Code: 600, Message: "HCS validation failed"}
+	ErrInvalidHCSAuthToken       = &APIError{Code: 601, Message: "HCS Invalid Auth Token"}
+	ErrHCSNotEnabled             = &APIError{Code: 602, Message: "HCS Service Not Enabled"}
+	ErrMaxTenantsHCS             = &APIError{Code: 603, Message: "HCS Max tenants exceeded"}
+	ErrEveryStreamDisabled       = &APIError{Code: 700, Message: "EveryStream Service Not Enabled"}
+	ErrEveryStreamNotFound       = &APIError{Code: 701, Message: "EveryStream Account Not Found"}
+	ErrEveryStreamSuspended      = &APIError{Code: 702, Message: "EveryStream Account Suspended"}
+	ErrEncodingJobStarted        = &APIError{Code: 703, Message: "Encoding Job already in progress"}
+	ErrEveryStreamQuota          = &APIError{Code: 704, Message: "EveryStream encoded quota exceeded"}
+	ErrTransmuxDisabled          = &APIError{Code: 705, Message: "Transmux Service is not enabled on this account"}
+	ErrTransmuxUnprovisioned     = &APIError{Code: 706, Message: "Transmux Service has not been provisioned"}
+	ErrTransmuxSuspended         = &APIError{Code: 707, Message: "Transmux Service is suspended"}
 )
@@ -0,0 +1,113 @@
+package striketracker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+func TestAPIErrorMatching(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "striketracker.highwinds.com", Path: "/api/v1/accounts/abc123/origins/1"}}
+
+	var testSuite = []struct {
+		name     string
+		err      *APIError
+		target   error
+		expected bool
+	}{
+		{
+			name:     "envelope code matches sentinel",
+			err:      NewAPIError(&http.Response{StatusCode: 400, Request: req}, 401, "A duplicate origin exists"),
+			target:   ErrDuplicateOrigin,
+			expected: true,
+		},
+		{
+			name:     "envelope code does not match other sentinel",
+			err:      NewAPIError(&http.Response{StatusCode: 400, Request: req}, 401, "A duplicate origin exists"),
+			target:   ErrValidationFailure,
+			expected: false,
+		},
+		{
+			name:     "bare not found status matches sentinel",
+			err:      NewAPIError(&http.Response{StatusCode: 404, Request: req}, 0, ""),
+			target:   ErrNotFound,
+			expected: true,
+		},
+		{
+			name:     "bare rate limit status matches sentinel",
+			err:      NewAPIError(&http.Response{StatusCode: 429, Request: req}, 0, ""),
+			target:   ErrLimitExceeded,
+			expected: true,
+		},
+		{
+			name:     "bare unauthorized status matches by meaning",
+			err:      NewAPIError(&http.Response{StatusCode: 401, Request: req}, 0, ""),
+			target:   ErrUnauthenticated,
+			expected: true,
+		},
+		{
+			name:     "bare unauthorized status does not match code 401",
+			err:      NewAPIError(&http.Response{StatusCode: 401, Request: req}, 0, ""),
+			target:   ErrDuplicateOrigin,
+			expected: false,
+		},
+		{
+			name:     "unmapped bare status matches nothing",
+			err:      NewAPIError(&http.Response{StatusCode: 405, Request: req}, 0, ""),
+			target:   ErrEndpointNotFound,
+			expected: false,
+		},
+		{
+			name:     "wrapped error still matches",
+			err:      NewAPIError(&http.Response{StatusCode: 404, Request: req}, 404, "The requested resource was not found"),
+			target:   ErrNotFound,
+			expected: true,
+		},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("fetching origin: %w", tt.err)
+			if found := errors.Is(wrapped, tt.target); found != tt.expected {
+				t.Fatalf("Expected errors.Is(%v, %v) to be %v", tt.err, tt.target, tt.expected)
+			}
+
+			var apiErr *APIError
+			if !errors.As(wrapped, &apiErr) {
+				t.Fatalf("Expected errors.As to extract *APIError")
+			}
+			if apiErr.URL != req.URL.String() {
+				t.Fatalf("Expected URL %s but got %s", req.URL.String(), apiErr.URL)
+			}
+		})
+	}
+}
+
+func TestAPIErrorString(t *testing.T) {
+	if found := ErrNotFound.Error(); found != "404: The requested resource was not found" {
+		t.Fatalf("Expected legacy formatted error string but got %s", found)
+	}
+
+	bare := NewAPIError(&http.Response{StatusCode: 502}, 0, "")
+	if found := bare.Error(); found != "502 Bad Gateway" {
+		t.Fatalf("Expected HTTP status for error without envelope but got %s", found)
+	}
+}
+
+func TestResponseErrorMatchesSentinels(t *testing.T) {
+	err := (&models.Response{Code: 303, Message: "The authenticated user does not have an associated account"}).Error()
+
+	if err.Error() != ErrUnassociatedUser.Error() {
+		t.Fatalf("Expected error %s but got %v", ErrUnassociatedUser, err)
+	}
+	if !errors.Is(err, ErrUnassociatedUser) {
+		t.Fatalf("Expected errors.Is to match %s but it did not", ErrUnassociatedUser)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected errors.Is not to match %s but it did", ErrNotFound)
+	}
+}
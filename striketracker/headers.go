@@ -0,0 +1,109 @@
+package striketracker
+
+import (
+	"net/http"
+	"sync"
+)
+
+// headerSet holds the default headers of a client and its views
+//
+// Writers replace the whole slice so readers always see a consistent set
+type headerSet struct {
+	mu      sync.RWMutex
+	headers []*Header
+}
+
+// snapshot returns a copy of the current headers
+func (h *headerSet) snapshot() []*Header {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]*Header, len(h.headers))
+	for i, header := range h.headers {
+		out[i] = &Header{Key: header.Key, Value: header.Value}
+	}
+	return out
+}
+
+// apply sets every header on the request
+func (h *headerSet) apply(req *http.Request) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, header := range h.headers {
+		req.Header.Set(header.Key, header.Value)
+	}
+}
+
+// replace swaps in a copy of headers
+func (h *headerSet) replace(headers []*Header) {
+	next := make([]*Header, len(headers))
+	for i, header := range headers {
+		next[i] = &Header{Key: header.Key, Value: header.Value}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.headers = next
+}
+
+// update applies fn to a copy of the headers and swaps it in
+func (h *headerSet) update(fn func([]*Header) []*Header) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	next := make([]*Header, 0, len(h.headers)+1)
+	for _, header := range h.headers {
+		next = append(next, &Header{Key: header.Key, Value: header.Value})
+	}
+	h.headers = fn(next)
+}
+
+// Headers returns a copy of the default headers attached to every request
+func (c *Client) Headers() []*Header {
+	return c.headers.snapshot()
+}
+
+// SetHeaders atomically replaces the default headers attached to every request
+func (c *Client) SetHeaders(headers []*Header) {
+	c.headers.replace(headers)
+}
+
+// SetHeader atomically adds or replaces a default header
+//
+// It is safe to call while requests are in flight, and affects every view
+// returned by ForAccount
+func (c *Client) SetHeader(key string, value string) {
+	key = http.CanonicalHeaderKey(key)
+	c.headers.update(func(headers []*Header) []*Header {
+		for _, header := range headers {
+			if http.CanonicalHeaderKey(header.Key) == key {
+				header.Value = value
+				return headers
+			}
+		}
+		return append(headers, &Header{Key: key, Value: value})
+	})
+}
+
+// DelHeader atomically removes a default header
+func (c *Client) DelHeader(key string) {
+	key = http.CanonicalHeaderKey(key)
+	c.headers.update(func(headers []*Header) []*Header {
+		out := headers[:0]
+		for _, header := range headers {
+			if http.CanonicalHeaderKey(header.Key) != key {
+				out = append(out, header)
+			}
+		}
+		return out
+	})
+}
+
+// SetToken atomically rotates the authorization token of a live client
+//
+// Requests built after the call use the new token, requests already built
+// keep the token they were signed with
+func (c *Client) SetToken(token string) {
+	c.Identity.SetToken(token)
+}
@@ -2,19 +2,101 @@
 // authentication for the user interfacing with the API
 package identity
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Token is a bearer token with an optional expiry
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time // zero for tokens which never expire
+}
+
+// Expired reports whether the token expires within the given window
+func (t *Token) Expired(window time.Duration) bool {
+	if t == nil || t.AccessToken == "" {
+		return true
+	}
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(window).After(t.Expiry)
+}
+
+// TokenSource supplies bearer tokens, refreshing them as required
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
 
 // Identification defines the user
+//
+// A TokenSource takes precedence over the permanent AuthorizationHeaderToken
+//
+// Identification is safe for concurrent use when accessed through its
+// methods, so credentials can be rotated on a live client with SetToken
+// while requests are in flight.
 type Identification struct {
+	// Deprecated: AuthorizationHeaderToken is not synchronized, reading or
+	// writing it races with requests in flight. Use Token and SetToken.
 	AuthorizationHeaderToken string
+	Source                   TokenSource
+
+	mu sync.RWMutex
 }
 
-// SetToken sets the header token
+// SetToken atomically replaces the header token
 func (i *Identification) SetToken(token string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
 	i.AuthorizationHeaderToken = token
 }
 
+// Token returns the current header token
+func (i *Identification) Token() string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.AuthorizationHeaderToken
+}
+
+// SetTokenSource atomically replaces the source of bearer tokens
+func (i *Identification) SetTokenSource(source TokenSource) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.Source = source
+}
+
 // GetBearer returns the formatted bearer token
 func (i *Identification) GetBearer() string {
-	return fmt.Sprintf("Bearer %s", i.AuthorizationHeaderToken)
+	return fmt.Sprintf("Bearer %s", i.Token())
+}
+
+// Bearer returns the Authorization header value for a request, fetching a
+// token from the TokenSource if one is configured
+//
+// An empty value means no authorization is available
+func (i *Identification) Bearer(ctx context.Context) (string, error) {
+	i.mu.RLock()
+	source, token := i.Source, i.AuthorizationHeaderToken
+	i.mu.RUnlock()
+
+	if source != nil {
+		t, err := source.Token(ctx)
+		if err != nil {
+			return "", err
+		}
+		if t == nil || t.AccessToken == "" {
+			return "", nil
+		}
+		return fmt.Sprintf("Bearer %s", t.AccessToken), nil
+	}
+
+	if token != "" {
+		return fmt.Sprintf("Bearer %s", token), nil
+	}
+
+	return "", nil
 }
@@ -0,0 +1,181 @@
+package striketracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Redacted replaces secret values in debug logs
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are JSON keys whose values never reach a log
+//
+//  key                         models.Certificate private key
+//  token                       models.Authentication & models.AccessToken
+//  access_token, refresh_token models.AuthToken
+//  password                    models.APITokenRequest
+var sensitiveFields = map[string]bool{
+	"key":           true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+}
+
+// Fields are the structured values attached to a log record
+type Fields map[string]interface{}
+
+// Logger receives structured debug records from the client
+//
+// Records are only emitted when the client is in debug mode
+type Logger interface {
+	Log(msg string, fields Fields)
+}
+
+// LoggerFunc adapts an ordinary function to a Logger
+type LoggerFunc func(msg string, fields Fields)
+
+// Log calls f(msg, fields)
+func (f LoggerFunc) Log(msg string, fields Fields) {
+	f(msg, fields)
+}
+
+// stdLogger writes records through the standard library logger
+type stdLogger struct {
+	l *log.Logger
+}
+
+// NewStdLogger returns a Logger writing sorted key=value pairs to l
+func NewStdLogger(l *log.Logger) Logger {
+	return &stdLogger{l: l}
+}
+
+// Log writes the record on a single line
+func (s *stdLogger) Log(msg string, fields Fields) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%q", k, fmt.Sprint(fields[k]))
+	}
+	s.l.Print(b.String())
+}
+
+// defaultLogger is used in debug mode when no Logger is configured
+func defaultLogger() Logger {
+	return NewStdLogger(log.New(os.Stderr, "striketracker: ", log.LstdFlags))
+}
+
+// logRequest records a completed exchange when in debug mode
+func (c *Client) logRequest(req *http.Request, resp *http.Response, body []byte, start time.Time, err error) {
+	if !c.Debug {
+		return
+	}
+
+	fields := Fields{
+		"method":          req.Method,
+		"url":             req.URL.String(),
+		"latency":         time.Since(start).String(),
+		"request_headers": redactHeaders(req.Header),
+	}
+
+	if req.GetBody != nil {
+		if rc, gerr := req.GetBody(); gerr == nil {
+			if reqBody, rerr := ioutil.ReadAll(rc); rerr == nil && len(reqBody) > 0 {
+				fields["request_body"] = string(redactBody(req.Header.Get("Content-Type"), reqBody))
+			}
+		}
+	}
+
+	if resp != nil {
+		fields["status"] = resp.StatusCode
+		if len(body) > 0 {
+			fields["response_body"] = string(RedactJSON(body))
+		}
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	c.logger.Log("striketracker request", fields)
+}
+
+// redactHeaders returns a copy of the headers with credentials removed
+func redactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+Redacted)
+	}
+	return out
+}
+
+// redactBody redacts a request body according to its content type
+func redactBody(contentType string, body []byte) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return RedactForm(body)
+	}
+	return RedactJSON(body)
+}
+
+// RedactForm replaces the values of sensitive fields in a URL encoded form
+// such as the password grant sent to /auth/token
+func RedactForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return []byte(Redacted)
+	}
+	for k := range form {
+		if sensitiveFields[strings.ToLower(k)] {
+			form.Set(k, Redacted)
+		}
+	}
+	return []byte(form.Encode())
+}
+
+// RedactJSON replaces the values of sensitive fields anywhere in a JSON
+// document, bodies which are not JSON are returned unchanged
+func RedactJSON(body []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(redactValue(doc))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactValue walks a decoded JSON document
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if _, ok := val.(string); ok && sensitiveFields[strings.ToLower(k)] {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactValue(val)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,104 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugLoggingRedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"application":"app","ip":"127.0.0.1","token":"SECRETRESPONSETOKEN"}`))
+	}))
+	defer server.Close()
+
+	var records []Fields
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithDebug(true),
+		WithLogger(LoggerFunc(func(msg string, fields Fields) {
+			records = append(records, fields)
+		})),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	payload := map[string]interface{}{
+		"certificate": "CERT",
+		"key":         "SECRETPRIVATEKEY",
+		"nested":      map[string]string{"password": "SECRETPASSWORD"},
+	}
+	req, err := c.NewRequestContext(context.Background(), POST, server.URL, payload)
+	if err != nil {
+		t.Fatalf("Expected request to build but got: %v", err)
+	}
+
+	if _, err = c.DoRequest(req, nil); err != nil {
+		t.Fatalf("Expected request to succeed but got: %v", err)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("Expected a single debug record but got %d", len(records))
+	}
+
+	record := records[0]
+	for _, key := range []string{"method", "url", "status", "latency", "request_body", "response_body"} {
+		if _, ok := record[key]; !ok {
+			t.Fatalf("Expected debug record to contain %s but got %v", key, record)
+		}
+	}
+
+	if record["status"] != http.StatusOK {
+		t.Fatalf("Expected status 200 in record but got %v", record["status"])
+	}
+
+	flat := strings.Join([]string{
+		record["request_body"].(string),
+		record["response_body"].(string),
+		record["request_headers"].(http.Header).Get("Authorization"),
+	}, " ")
+
+	for _, secret := range []string{"SECRETPRIVATEKEY", "SECRETPASSWORD", "SECRETRESPONSETOKEN", TestToken} {
+		if strings.Contains(flat, secret) {
+			t.Fatalf("Expected %s to be redacted from debug record but found: %s", secret, flat)
+		}
+	}
+
+	if !strings.Contains(flat, "CERT") {
+		t.Fatalf("Expected non-sensitive fields to be logged but found: %s", flat)
+	}
+
+	if req.Header.Get("Authorization") != "Bearer "+TestToken {
+		t.Fatalf("Expected redaction not to modify the outgoing request")
+	}
+}
+
+func TestLoggingDisabledWithoutDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	logged := false
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithLogger(LoggerFunc(func(msg string, fields Fields) {
+			logged = true
+		})),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	req, _ := c.NewRequestContext(context.Background(), GET, server.URL, nil)
+	c.DoRequest(req, nil)
+
+	if logged {
+		t.Fatalf("Expected no debug records when Debug is disabled")
+	}
+}
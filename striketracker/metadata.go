@@ -0,0 +1,102 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestIDHeaders are checked in order for an identifier of the request
+// to quote in support tickets
+var RequestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+// RateLimit headers reported by the API
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimitInfo describes the rate limit state reported with a response
+//
+// Fields are left zero when the headers are absent
+type RateLimitInfo struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ResponseMetadata describes the HTTP exchange behind a service call
+type ResponseMetadata struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	RequestID  string
+	RateLimit  RateLimitInfo
+	Duration   time.Duration
+}
+
+type metadataKey struct{}
+
+// WithResponseMetadata returns a context which captures the response metadata
+// of the call it is passed to, allowing any service method to report it
+//
+//  var meta striketracker.ResponseMetadata
+//  origin, err := o.Get(striketracker.WithResponseMetadata(ctx, &meta), accountHash, originID)
+//  log.Printf("request %s returned %d with %d calls remaining", meta.RequestID, meta.StatusCode, meta.RateLimit.Remaining)
+//
+// Metadata is captured for failed calls as well, and reflects the last
+// attempt when retries are enabled. When no response was received only
+// Method, URL and Duration are set.
+//
+// The metadata is written without synchronization, so concurrent calls
+// such as RunBatch operations each need their own context and metadata.
+func WithResponseMetadata(ctx context.Context, meta *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, meta)
+}
+
+// captureMetadata fills the metadata requested on the request context, if any
+func captureMetadata(req *http.Request, resp *http.Response, start time.Time) {
+	meta, ok := req.Context().Value(metadataKey{}).(*ResponseMetadata)
+	if !ok || meta == nil {
+		return
+	}
+
+	*meta = *NewResponseMetadata(resp)
+	meta.Method = req.Method
+	meta.URL = req.URL.String()
+	meta.Duration = time.Since(start)
+}
+
+// NewResponseMetadata extracts metadata from an HTTP response
+func NewResponseMetadata(resp *http.Response) *ResponseMetadata {
+	meta := &ResponseMetadata{}
+	if resp == nil {
+		return meta
+	}
+
+	meta.StatusCode = resp.StatusCode
+	meta.Header = resp.Header
+
+	for _, header := range RequestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			meta.RequestID = id
+			break
+		}
+	}
+
+	meta.RateLimit.Limit, _ = strconv.Atoi(resp.Header.Get(RateLimitLimitHeader))
+	meta.RateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get(RateLimitRemainingHeader))
+	if reset, err := strconv.ParseInt(resp.Header.Get(RateLimitResetHeader), 10, 64); err == nil {
+		meta.RateLimit.Reset = time.Unix(reset, 0)
+	}
+
+	if resp.Request != nil && resp.Request.URL != nil {
+		meta.Method = resp.Request.Method
+		meta.URL = resp.Request.URL.String()
+	}
+
+	return meta
+}
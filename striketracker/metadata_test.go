@@ -0,0 +1,80 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseMetadataCapture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-8675309")
+		w.Header().Set(RateLimitLimitHeader, "100")
+		w.Header().Set(RateLimitRemainingHeader, "42")
+		w.Header().Set(RateLimitResetHeader, "1577934245")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	var meta ResponseMetadata
+	ctx := WithResponseMetadata(context.Background(), &meta)
+
+	if _, err = c.Call(ctx, GET, server.URL+"/ok", nil, nil, nil); err != nil {
+		t.Fatalf("Expected call to succeed but got: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.Method != "GET" || meta.URL != server.URL+"/ok" {
+		t.Fatalf("Expected exchange details to be captured but got %+v", meta)
+	}
+
+	if meta.RequestID != "req-8675309" {
+		t.Fatalf("Expected request ID req-8675309 but got %s", meta.RequestID)
+	}
+
+	expected := RateLimitInfo{Limit: 100, Remaining: 42, Reset: time.Unix(1577934245, 0)}
+	if meta.RateLimit != expected {
+		t.Fatalf("Expected rate limit %+v but got %+v", expected, meta.RateLimit)
+	}
+
+	if _, err = c.Call(ctx, GET, server.URL+"/missing", nil, nil, nil); err == nil {
+		t.Fatalf("Expected call to fail")
+	}
+
+	if meta.StatusCode != http.StatusNotFound || meta.RequestID != "req-8675309" {
+		t.Fatalf("Expected metadata to be captured for failed calls but got %+v", meta)
+	}
+}
+
+func TestResponseMetadataTransportError(t *testing.T) {
+	failure := errors.New("connection refused")
+	config := *BaseConfiguration
+	config.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, failure
+	})
+	c, err := NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	meta := ResponseMetadata{StatusCode: http.StatusTeapot}
+	ctx := WithResponseMetadata(context.Background(), &meta)
+	if _, err = c.Call(ctx, GET, "http://example.com/origins", nil, nil, nil); !errors.Is(err, failure) {
+		t.Fatalf("Expected transport error but got: %v", err)
+	}
+
+	if meta.Method != "GET" || meta.URL != "http://example.com/origins" || meta.StatusCode != 0 || meta.Duration <= 0 {
+		t.Fatalf("Expected request metadata without a response but got %+v", meta)
+	}
+}
@@ -0,0 +1,30 @@
+package striketracker
+
+import "net/http"
+
+// Handler performs a request and decodes the response into v
+//
+// The error is an *APIError when Striketracker rejected the request
+type Handler func(req *http.Request, v interface{}) (*http.Response, error)
+
+// Middleware wraps a Handler to inspect or alter requests, decoded models
+// and errors, such as injecting headers, auditing mutations or measuring latency
+//
+//  audit := func(next striketracker.Handler) striketracker.Handler {
+//  	return func(req *http.Request, v interface{}) (*http.Response, error) {
+//  		resp, err := next(req, v)
+//  		if req.Method != http.MethodGet {
+//  			log.Printf("%s %s: %v", req.Method, req.URL, err)
+//  		}
+//  		return resp, err
+//  	}
+//  }
+type Middleware func(next Handler) Handler
+
+// chain wraps h so the first middleware is the outermost
+func chain(h Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
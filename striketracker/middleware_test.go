@@ -0,0 +1,79 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace-Id") != "trace-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"A required parameter is missing from a request","code":2}`))
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"The requested resource was not found","code":404}`))
+			return
+		}
+		w.Write([]byte(`{"name":"origin"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	var seenModel string
+	var seenErr error
+
+	trace := func(next Handler) Handler {
+		return func(req *http.Request, v interface{}) (*http.Response, error) {
+			order = append(order, "trace")
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(req, v)
+		}
+	}
+	audit := func(next Handler) Handler {
+		return func(req *http.Request, v interface{}) (*http.Response, error) {
+			order = append(order, "audit")
+			resp, err := next(req, v)
+			if m, ok := v.(*map[string]string); ok {
+				seenModel = (*m)["name"]
+			}
+			seenErr = err
+			return resp, err
+		}
+	}
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithMiddleware(trace),
+		WithMiddleware(audit),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	out := map[string]string{}
+	req, _ := c.NewRequestContext(context.Background(), GET, server.URL+"/ok", nil)
+	if _, err = c.DoRequest(req, &out); err != nil {
+		t.Fatalf("Expected injected header to satisfy the server but got: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "trace" || order[1] != "audit" {
+		t.Fatalf("Expected middleware to run in configured order but got %v", order)
+	}
+
+	if seenModel != "origin" {
+		t.Fatalf("Expected middleware to observe the decoded model but got %q", seenModel)
+	}
+
+	req, _ = c.NewRequestContext(context.Background(), GET, server.URL+"/missing", nil)
+	c.DoRequest(req, &out)
+	if !errors.Is(seenErr, ErrNotFound) {
+		t.Fatalf("Expected middleware to observe the Striketracker error but got %v", seenErr)
+	}
+}
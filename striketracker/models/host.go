@@ -0,0 +1,62 @@
+package models
+
+/*
+GET /api/v1/accounts/{account_hash}/hosts - list all hosts
+POST /api/v1/accounts/{account_hash}/hosts - create a host
+GET /api/v1/accounts/{account_hash}/hosts/{host_hash} - get one host
+PUT /api/v1/accounts/{account_hash}/hosts/{host_hash} - update a host
+DELETE /api/v1/accounts/{account_hash}/hosts/{host_hash} - delete a host
+*/
+
+import (
+	"github.com/openwurl/wurlwind/pkg/validation"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// HostList unwraps a list of hosts from the API
+type HostList struct {
+	List []Host `json:"list"`
+}
+
+// Host is a CDN delivery host, the configuration unit bound to hostnames and origins
+type Host struct {
+	Response
+	// Required
+	Name string `json:"name" validate:"required"`
+
+	// Optional
+	HashCode    string         `json:"hashCode,omitempty"`
+	Type        string         `json:"type,omitempty"` // Default HOST
+	CreatedDate string         `json:"createdDate,omitempty"`
+	UpdatedDate string         `json:"updatedDate,omitempty"`
+	Services    []*HostService `json:"services,omitempty"`
+	Scopes      []*HostScope   `json:"scopes,omitempty"`
+}
+
+// HostService is a CDN service enabled on a host
+type HostService struct {
+	ID          int    `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// HostScope is a platform and path a host's configuration applies to
+type HostScope struct {
+	ID          int    `json:"id,omitempty"`
+	Platform    string `json:"platform,omitempty"`
+	Path        string `json:"path,omitempty"`
+	CreatedDate string `json:"createdDate,omitempty"`
+	UpdatedDate string `json:"updatedDate,omitempty"`
+}
+
+// Validate validates the struct data
+func (h *Host) Validate() error {
+	v := validation.NewValidator(validator.New())
+	if err := v.Validate(h); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -8,20 +8,38 @@ type Response struct {
 	Code    int    `json:"code,omitempty"`
 }
 
-// Error returns the formatted error of the embedded if it exists
+// ResponseError is the error envelope embedded in a response
+type ResponseError struct {
+	Code    int
+	Message string
+}
+
+// Error returns the error in the Striketracker "code: message" format
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// Is matches any error reporting the same Striketracker code through an
+// ErrorCode method, such as the striketracker error sentinels
+func (e *ResponseError) Is(target error) bool {
+	t, ok := target.(interface{ ErrorCode() int })
+	return ok && t.ErrorCode() == e.Code
+}
+
+// Error returns the embedded error as a *ResponseError if it exists
 //
 // You can use the provided errors to conveniently match ones you may expect
 //
 //	 if err != nil {
-//	 	 if err == striketracker.ErrUnauthenticated {
+//	 	 if errors.Is(err, striketracker.ErrUnauthenticated) {
 //		 	 // handle
-//		 } else if err == striketracker.ErrResourceExists {
+//		 } else if errors.Is(err, striketracker.ErrResourceExists) {
 //			 // handle
 //		 }
 //	 }
 func (r *Response) Error() error {
 	if r.Message != "" {
-		return fmt.Errorf("%d: %s", r.Code, r.Message)
+		return &ResponseError{Code: r.Code, Message: r.Message}
 	}
 	return nil
 }
@@ -1,11 +1,16 @@
 package models
 
 import (
+	"errors"
 	"testing"
-
-	"github.com/openwurl/wurlwind/striketracker"
 )
 
+// codedError stands in for the striketracker error sentinels
+type codedError int
+
+func (e codedError) Error() string  { return "coded" }
+func (e codedError) ErrorCode() int { return int(e) }
+
 func TestResponseErr(t *testing.T) {
 	r := &Response{
 		Code:    303,
@@ -13,7 +18,20 @@ func TestResponseErr(t *testing.T) {
 	}
 
 	err := r.Error()
-	if err.Error() != striketracker.ErrUnassociatedUser {
-		t.Fatalf("expected error %s but got %v", striketracker.ErrUnassociatedUser, err)
+	if err.Error() != "303: The authenticated user does not have an associated account" {
+		t.Fatalf("expected code: message format but got %v", err)
+	}
+
+	if !errors.Is(err, codedError(303)) {
+		t.Fatalf("expected errors.Is to match code 303 but it did not")
+	}
+
+	if errors.Is(err, codedError(404)) {
+		t.Fatalf("expected errors.Is not to match code 404 but it did")
+	}
+
+	r = &Response{}
+	if err = r.Error(); err != nil {
+		t.Fatalf("expected no error from an empty response but got %v", err)
 	}
 }
@@ -0,0 +1,136 @@
+package striketracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/openwurl/wurlwind/pkg/fileio"
+)
+
+// Environment variables selecting the configuration file and profile
+const (
+	ConfigFileEnv = "STRIKETRACKER_CONFIG_FILE"
+	ProfileEnv    = "STRIKETRACKER_PROFILE"
+)
+
+// DefaultProfile is used when no profile is selected
+const DefaultProfile = "default"
+
+// ConfigFile is a credentials file holding named profiles
+//
+// Each profile uses the same keys as Configuration
+//
+//  {
+//  	"profiles": {
+//  		"default": {
+//  			"authorizationHeaderToken": "...",
+//  			"applicationID": "DescriptiveApplicationName",
+//  			"timeout": 10,
+//  			"accountHash": "f98fsj32k"
+//  		},
+//  		"staging": {
+//  			"authorizationHeaderToken": "...",
+//  			"applicationID": "DescriptiveApplicationName",
+//  			"baseURL": "https://staging.example.com"
+//  		}
+//  	}
+//  }
+type ConfigFile struct {
+	Profiles map[string]*Configuration `json:"profiles"`
+}
+
+// DefaultConfigFilePath returns ~/.striketracker/config.json
+func DefaultConfigFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".striketracker", "config.json"), nil
+}
+
+// LoadConfigurationFile loads the named profile from a credentials file
+//
+// An empty path or profile falls back to the STRIKETRACKER_CONFIG_FILE and
+// STRIKETRACKER_PROFILE environment variables, then to
+// ~/.striketracker/config.json and the "default" profile
+func LoadConfigurationFile(path string, profile string) (*Configuration, error) {
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path == "" {
+		defaultPath, err := DefaultConfigFilePath()
+		if err != nil {
+			return nil, err
+		}
+		path = defaultPath
+	}
+
+	if profile == "" {
+		profile = os.Getenv(ProfileEnv)
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	contents, err := fileio.FileToString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ConfigFile{}
+	if err = json.Unmarshal([]byte(contents), file); err != nil {
+		return nil, fmt.Errorf("parsing configuration file %s: %w", path, err)
+	}
+
+	config, ok := file.Profiles[profile]
+	if !ok || config == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+
+	return config, nil
+}
+
+// usesConfigFile reports whether a file was requested by option or environment
+//
+// The environment is only consulted when no credentials were given, so an
+// explicit token, username, token source or credential providers are never
+// replaced by a profile selected for another client in the process
+func (c *Configuration) usesConfigFile() bool {
+	if c.ConfigFile != "" || c.Profile != "" {
+		return true
+	}
+	if c.hasCredentials() {
+		return false
+	}
+	return os.Getenv(ConfigFileEnv) != "" || os.Getenv(ProfileEnv) != ""
+}
+
+// hasCredentials reports whether any form of authorization was configured
+func (c *Configuration) hasCredentials() bool {
+	return c.AuthorizationHeaderToken != "" || c.Username != "" || c.TokenSource != nil || len(c.CredentialProviders) > 0
+}
+
+// overlay copies the non-zero fields of other over the configuration, then
+// re-applies the options other was built with by NewConfiguration
+//
+// A false boolean cannot be told apart from one left unset, so it only
+// overrides the configuration through an option such as WithDebug(false)
+func (c *Configuration) overlay(other *Configuration) {
+	dst := reflect.ValueOf(c).Elem()
+	src := reflect.ValueOf(other).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		if field := src.Field(i); !field.IsZero() {
+			dst.Field(i).Set(field)
+		}
+	}
+
+	for _, option := range other.options {
+		option(c)
+	}
+}
@@ -0,0 +1,198 @@
+package striketracker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigFile = `{
+	"profiles": {
+		"default": {
+			"authorizationHeaderToken": "defaulttoken",
+			"applicationID": "DefaultApplication",
+			"timeout": 10,
+			"accountHash": "defaulthash"
+		},
+		"staging": {
+			"authorizationHeaderToken": "stagingtoken",
+			"applicationID": "StagingApplication",
+			"timeout": 20,
+			"accountHash": "staginghash",
+			"baseURL": "https://staging.example.com",
+			"debug": true,
+			"dryRun": true
+		}
+	}
+}`
+
+// writeTestConfigFile writes the test profiles to a temporary file
+func writeTestConfigFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "striketracker")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	path := filepath.Join(dir, "config.json")
+	if err = ioutil.WriteFile(path, []byte(testConfigFile), 0600); err != nil {
+		t.Fatalf("Expected to write configuration file but got: %v", err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadConfigurationFile(t *testing.T) {
+	path, cleanup := writeTestConfigFile(t)
+	defer cleanup()
+
+	config, err := LoadConfigurationFile(path, "")
+	if err != nil {
+		t.Fatalf("Expected default profile to load but got: %v", err)
+	}
+	if config.AuthorizationHeaderToken != "defaulttoken" || config.AccountHash != "defaulthash" || config.Timeout != 10 {
+		t.Fatalf("Expected default profile values but got %+v", config)
+	}
+
+	os.Setenv(ProfileEnv, "staging")
+	defer os.Unsetenv(ProfileEnv)
+
+	config, err = LoadConfigurationFile(path, "")
+	if err != nil {
+		t.Fatalf("Expected profile selected by environment to load but got: %v", err)
+	}
+	if config.ApplicationID != "StagingApplication" {
+		t.Fatalf("Expected staging profile from %s but got %+v", ProfileEnv, config)
+	}
+
+	if _, err = LoadConfigurationFile(path, "missing"); err == nil {
+		t.Fatalf("Expected an error for a missing profile")
+	}
+}
+
+func TestNewClientWithProfile(t *testing.T) {
+	path, cleanup := writeTestConfigFile(t)
+	defer cleanup()
+
+	c, err := NewClientWithOptions(
+		WithRequestTimeout(3),
+		WithConfigFile(path),
+		WithProfile("staging"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure from profile but got: %v", err)
+	}
+
+	if c.Identity.Token() != "stagingtoken" {
+		t.Fatalf("Expected token from profile but got %s", c.Identity.Token())
+	}
+
+	if c.BaseURL != "https://staging.example.com" {
+		t.Fatalf("Expected base URL from profile but got %s", c.BaseURL)
+	}
+
+	if c.HTTPClient().Timeout != 3*time.Second {
+		t.Fatalf("Expected explicit timeout option to take precedence but got %v", c.HTTPClient().Timeout)
+	}
+
+	c, err = NewClientWithOptions(
+		WithConfigFile(path),
+		WithApplicationID("ExplicitApplication"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure from default profile but got: %v", err)
+	}
+
+	if c.ApplicationID != "ExplicitApplication" {
+		t.Fatalf("Expected explicit application ID to take precedence but got %s", c.ApplicationID)
+	}
+
+	if _, err = NewClientWithOptions(WithConfigFile(filepath.Join(filepath.Dir(path), "missing.json"))); err == nil {
+		t.Fatalf("Expected an error for a missing configuration file")
+	}
+}
+
+func TestNewClientFromConfigurationWithProfile(t *testing.T) {
+	path, cleanup := writeTestConfigFile(t)
+	defer cleanup()
+
+	config, err := NewConfiguration(
+		WithConfigFile(path),
+		WithProfile("staging"),
+		WithApplicationID("ExplicitApplication"),
+	)
+	if err != nil {
+		t.Fatalf("Expected configuration to build but got: %v", err)
+	}
+
+	c, err := NewClientFromConfiguration(config)
+	if err != nil {
+		t.Fatalf("Expected client to configure from profile but got: %v", err)
+	}
+
+	if c.Identity.Token() != "stagingtoken" || c.BaseURL != "https://staging.example.com" {
+		t.Fatalf("Expected token and base URL from profile but got %s and %s", c.Identity.Token(), c.BaseURL)
+	}
+
+	if c.ApplicationID != "ExplicitApplication" {
+		t.Fatalf("Expected explicit application ID to take precedence but got %s", c.ApplicationID)
+	}
+}
+
+func TestNewClientFromConfigurationBooleans(t *testing.T) {
+	path, cleanup := writeTestConfigFile(t)
+	defer cleanup()
+
+	// A false field of a struct literal is indistinguishable from an unset one
+	c, err := NewClientFromConfiguration(&Configuration{ConfigFile: path, Profile: "staging", Debug: false})
+	if err != nil {
+		t.Fatalf("Expected client to configure from profile but got: %v", err)
+	}
+	if !c.Debug || !c.DryRun() {
+		t.Fatalf("Expected the profile's debug and dry run to remain enabled")
+	}
+
+	config, err := NewConfiguration(WithConfigFile(path), WithProfile("staging"), WithDebug(false), WithDryRun(false))
+	if err != nil {
+		t.Fatalf("Expected configuration to build but got: %v", err)
+	}
+	if c, err = NewClientFromConfiguration(config); err != nil {
+		t.Fatalf("Expected client to configure from profile but got: %v", err)
+	}
+	if c.Debug || c.DryRun() {
+		t.Fatalf("Expected options to turn off the profile's debug and dry run")
+	}
+}
+
+func TestProfileEnvironmentPrecedence(t *testing.T) {
+	path, cleanup := writeTestConfigFile(t)
+	defer cleanup()
+
+	os.Setenv(ConfigFileEnv, path)
+	os.Setenv(ProfileEnv, "staging")
+	defer os.Unsetenv(ConfigFileEnv)
+	defer os.Unsetenv(ProfileEnv)
+
+	c, err := NewClientWithOptions(WithApplicationID("ExplicitApplication"))
+	if err != nil {
+		t.Fatalf("Expected client to configure from profile selected by environment but got: %v", err)
+	}
+	if c.Identity.Token() != "stagingtoken" {
+		t.Fatalf("Expected token from environment profile but got %s", c.Identity.Token())
+	}
+
+	c, err = NewClientWithOptions(
+		WithApplicationID("ExplicitApplication"),
+		WithAuthorizationHeaderToken("explicittoken"),
+	)
+	if err != nil {
+		t.Fatalf("Expected explicit credentials to ignore the environment profile but got: %v", err)
+	}
+	if c.Identity.Token() != "explicittoken" || c.BaseURL == "https://staging.example.com" {
+		t.Fatalf("Expected explicit token without the environment profile but got %s at %s", c.Identity.Token(), c.BaseURL)
+	}
+
+	os.Setenv(ConfigFileEnv, filepath.Join(filepath.Dir(path), "missing.json"))
+	if _, err = NewClientWithOptions(WithApplicationID("ExplicitApplication"), WithAuthorizationHeaderToken("explicittoken")); err != nil {
+		t.Fatalf("Expected explicit credentials not to require the environment file but got: %v", err)
+	}
+}
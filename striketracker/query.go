@@ -0,0 +1,78 @@
+package striketracker
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Query builds query parameters for Client.Call
+//
+//  q := striketracker.NewQuery().
+//  	Set("granularity", "PT5M").
+//  	SetTime("startDate", start).
+//  	SetBool("recursive", true)
+type Query url.Values
+
+// NewQuery returns an empty Query
+func NewQuery() Query {
+	return Query{}
+}
+
+// Set sets key to value, empty values are omitted
+func (q Query) Set(key, value string) Query {
+	if value != "" {
+		url.Values(q).Set(key, value)
+	}
+	return q
+}
+
+// Add appends value to key, empty values are omitted
+func (q Query) Add(key, value string) Query {
+	if value != "" {
+		url.Values(q).Add(key, value)
+	}
+	return q
+}
+
+// SetInt sets key to the decimal value
+func (q Query) SetInt(key string, value int) Query {
+	url.Values(q).Set(key, strconv.Itoa(value))
+	return q
+}
+
+// SetBool sets key to true or false
+func (q Query) SetBool(key string, value bool) Query {
+	url.Values(q).Set(key, strconv.FormatBool(value))
+	return q
+}
+
+// SetTime sets key to the ISO 8601 time, zero times are omitted
+func (q Query) SetTime(key string, value time.Time) Query {
+	if !value.IsZero() {
+		url.Values(q).Set(key, value.UTC().Format(time.RFC3339))
+	}
+	return q
+}
+
+// Encode returns the URL encoded query sorted by key
+func (q Query) Encode() string {
+	return url.Values(q).Encode()
+}
+
+// appendQuery attaches the encoded query to URL
+func appendQuery(URL string, query Query) string {
+	if len(query) == 0 {
+		return URL
+	}
+	u, err := url.Parse(URL)
+	if err != nil {
+		return URL
+	}
+	values := u.Query()
+	for key, v := range query {
+		values[key] = append(values[key], v...)
+	}
+	u.RawQuery = values.Encode()
+	return u.String()
+}
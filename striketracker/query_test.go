@@ -0,0 +1,69 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	q := NewQuery().
+		Set("granularity", "PT5M").
+		Set("empty", "").
+		SetInt("limit", 10).
+		SetBool("recursive", true).
+		SetTime("startDate", start).
+		SetTime("endDate", time.Time{})
+
+	expected := "granularity=PT5M&limit=10&recursive=true&startDate=2020-01-02T03%3A04%3A05Z"
+	if found := q.Encode(); found != expected {
+		t.Fatalf("Expected query %s but got %s", expected, found)
+	}
+}
+
+// validatedModel fails validation when Name is empty
+type validatedModel struct {
+	Name string `json:"name"`
+}
+
+func (m *validatedModel) Validate() error {
+	if m.Name == "" {
+		return ErrMissingRequiredParameter
+	}
+	return nil
+}
+
+func TestCall(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Write([]byte(`{"name":"created"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	out := &validatedModel{}
+	_, err = c.Call(context.Background(), POST, server.URL+"/origins?existing=1", NewQuery().Set("search", "cdn"), &validatedModel{Name: "new"}, out)
+	if err != nil {
+		t.Fatalf("Expected call to succeed but got: %v", err)
+	}
+
+	if out.Name != "created" {
+		t.Fatalf("Expected decoded output but got %v", out)
+	}
+
+	if rawQuery != "existing=1&search=cdn" {
+		t.Fatalf("Expected query to be merged into URL but server saw %s", rawQuery)
+	}
+
+	if _, err = c.Call(context.Background(), POST, server.URL, nil, &validatedModel{}, nil); err != ErrMissingRequiredParameter {
+		t.Fatalf("Expected input validation to fail before sending but got: %v", err)
+	}
+}
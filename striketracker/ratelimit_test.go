@@ -0,0 +1,69 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstThenThrottle(t *testing.T) {
+	l := NewRateLimiter(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Expected no error waiting on limiter but got: %v", err)
+		}
+	}
+
+	// Two requests burst, the remaining two wait ~50ms each
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("Expected limiter to throttle after burst but 4 requests took %v", elapsed)
+	}
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected first request to pass immediately but got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected context deadline while waiting but got: %v", err)
+	}
+}
+
+func TestRateLimitSharedByClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithRateLimit(0.1, 1),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	req, _ := c.NewRequestContext(context.Background(), GET, server.URL, nil)
+	if _, err = c.DoRequest(req, nil); err != nil {
+		t.Fatalf("Expected first request to succeed but got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, _ = c.NewRequestContext(ctx, GET, server.URL, nil)
+	if _, err = c.DoRequest(req, nil); err != context.DeadlineExceeded {
+		t.Fatalf("Expected second request to be held by the limiter until the deadline but got: %v", err)
+	}
+}
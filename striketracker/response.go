@@ -0,0 +1,52 @@
+package striketracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// errorEnvelope is the error body Striketracker returns on failure
+type errorEnvelope struct {
+	Message string `json:"error"`
+	Code    int    `json:"code"`
+}
+
+// readBody consumes the response body and replaces it with a re-readable
+// copy so callers holding the response can inspect it for diagnostics
+func readBody(resp *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// decodeResponse checks the status before decoding anything
+//
+// Failures decode the {error, code} envelope into an *APIError, falling
+// back to the bare HTTP status for empty or non-JSON bodies such as a
+// gateway's HTML page. Successful empty bodies, such as a 204 from DELETE,
+// leave v untouched.
+func decodeResponse(resp *http.Response, body []byte, v interface{}) error {
+	if resp.StatusCode >= 400 {
+		apiErr := NewAPIError(resp, 0, "")
+		envelope := &errorEnvelope{}
+		if err := json.Unmarshal(body, envelope); err == nil && envelope.Message != "" {
+			apiErr.Code = envelope.Code
+			apiErr.Message = envelope.Message
+		}
+		apiErr.Body = body
+		return apiErr
+	}
+
+	if v == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding %d response from %s: %w", resp.StatusCode, resp.Request.URL, err)
+	}
+	return nil
+}
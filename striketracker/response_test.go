@@ -0,0 +1,96 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoRequestDecoding(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"origin"}`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/envelope", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"A duplicate origin exists with the same hostname, port, and path","code":401}`))
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html><body>502 Bad Gateway</body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := NewClient(BaseConfiguration)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	var testSuite = []struct {
+		name   string
+		path   string
+		status int
+		target error
+		result string
+	}{
+		{name: "success decodes model", path: "/ok", status: http.StatusOK, result: "origin"},
+		{name: "empty success body is tolerated", path: "/empty", status: http.StatusNoContent},
+		{name: "error envelope becomes APIError", path: "/envelope", status: http.StatusBadRequest, target: ErrDuplicateOrigin},
+		{name: "non-JSON failure keeps status", path: "/html", status: http.StatusBadGateway},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := c.NewRequestContext(context.Background(), GET, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatalf("Expected request to build but got: %v", err)
+			}
+
+			out := struct {
+				Name string `json:"name"`
+			}{}
+			resp, err := c.DoRequest(req, &out)
+
+			if resp == nil || resp.StatusCode != tt.status {
+				t.Fatalf("Expected response with status %d but got %v", tt.status, resp)
+			}
+
+			if tt.status < 400 {
+				if err != nil {
+					t.Fatalf("Expected no error but got: %v", err)
+				}
+				if out.Name != tt.result {
+					t.Fatalf("Expected decoded name %q but got %q", tt.result, out.Name)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError but got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Fatalf("Expected APIError status %d but got %d", tt.status, apiErr.StatusCode)
+			}
+			if tt.target != nil && !errors.Is(err, tt.target) {
+				t.Fatalf("Expected error to match %v but got %v", tt.target, err)
+			}
+			if len(apiErr.Body) == 0 {
+				t.Fatalf("Expected raw body to be preserved on the error")
+			}
+
+			raw, _ := ioutil.ReadAll(resp.Body)
+			if string(raw) != string(apiErr.Body) {
+				t.Fatalf("Expected raw body to remain readable from the response")
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package striketracker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Retry defaults used by DefaultRetryPolicy
+const (
+	DefaultMaxAttempts = 3
+	DefaultMinBackoff  = 500 * time.Millisecond
+	DefaultMaxBackoff  = 10 * time.Second
+	// DefaultMaxRetryAfter bounds the wait a server may request with Retry-After
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// RetryableStatusCodes are the HTTP statuses Striketracker uses for
+// conditions that may succeed when tried again
+//
+//  429 ErrLimitExceeded
+//  502 Bad Gateway
+//  503 ErrDatabaseDown
+//  504 ErrCDNUnresponsive
+//  505 ErrApplicationMaintenance
+var RetryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:         true,
+	http.StatusBadGateway:              true,
+	http.StatusServiceUnavailable:      true,
+	http.StatusGatewayTimeout:          true,
+	http.StatusHTTPVersionNotSupported: true,
+}
+
+// RetryPolicy configures automatic retries of transient failures
+//
+// Only idempotent methods are replayed unless RetryNonIdempotent is set.
+// JSON bodies are re-sent from a fresh copy on every attempt. Transport
+// errors are only retried when they are timeouts, temporary or connection
+// resets, so certificate, handshake and DNS lookup failures fail at once.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry, doubled on each attempt,
+	// DefaultMinBackoff when zero
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed backoff, DefaultMaxBackoff when zero
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps the wait requested by a Retry-After header,
+	// DefaultMaxRetryAfter when zero
+	MaxRetryAfter time.Duration
+	// RetryNonIdempotent allows POST and PATCH to be replayed
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts with 500ms-10s backoff,
+// honoring Retry-After up to a minute
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   DefaultMaxAttempts,
+		MinBackoff:    DefaultMinBackoff,
+		MaxBackoff:    DefaultMaxBackoff,
+		MaxRetryAfter: DefaultMaxRetryAfter,
+	}
+}
+
+// allows reports whether the method may be replayed under this policy
+func (p *RetryPolicy) allows(method string) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch HTTPMethod(method) {
+	case GET, HEAD, OPTIONS, TRACE, PUT, DELETE:
+		return true
+	}
+	return false
+}
+
+// orDefault returns d, or fallback when d is not set
+func orDefault(d time.Duration, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// backoff returns the wait before the given retry (1 indexed)
+// using exponential growth with equal jitter
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := orDefault(p.MinBackoff, DefaultMinBackoff)
+	limit := orDefault(p.MaxBackoff, DefaultMaxBackoff)
+	for i := 1; i < retry && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// capRetryAfter bounds a server requested wait by MaxRetryAfter
+func (p *RetryPolicy) capRetryAfter(wait time.Duration) time.Duration {
+	if limit := orDefault(p.MaxRetryAfter, DefaultMaxRetryAfter); wait > limit {
+		return limit
+	}
+	return wait
+}
+
+// retryableError reports whether a transport error may succeed when tried
+// again, such as a timeout or reset connection
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout() || netErr.Temporary()
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header in either seconds or HTTP date form
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// send performs the request, retrying transient failures under the client policy
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.allows(req.Method) {
+		return c.roundTrip(req)
+	}
+
+	ctx := req.Context()
+	attempt := req
+	for i := 1; ; i++ {
+		resp, err := c.roundTrip(attempt)
+
+		// Give up on success, exhaustion, or a non-transient failure
+		if i >= policy.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		if err == nil && !RetryableStatusCodes[resp.StatusCode] {
+			return resp, nil
+		}
+		if err != nil && !retryableError(err) {
+			return nil, err
+		}
+
+		wait := policy.backoff(i)
+		if err == nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = policy.capRetryAfter(after)
+			}
+			// Drain so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attempt = req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package striketracker
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// retryTestClient returns a client pointed at the given server with a fast retry policy
+func retryTestClient(t *testing.T, url string) *Client {
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithBaseURL(url),
+		WithRetryPolicy(&RetryPolicy{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  5 * time.Millisecond,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	return c
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	var attempts int32
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := retryTestClient(t, server.URL)
+
+	req, err := c.NewRequestContext(context.Background(), PUT, server.URL, map[string]string{"name": "ok"})
+	if err != nil {
+		t.Fatalf("Expected request to build but got: %v", err)
+	}
+
+	out := map[string]string{}
+	resp, err := c.DoRequest(req, &out)
+	if err != nil {
+		t.Fatalf("Expected request to succeed after retries but got: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || out["name"] != "ok" {
+		t.Fatalf("Expected decoded success response but got status %d and %v", resp.StatusCode, out)
+	}
+
+	if atomic.LoadInt32(&attempts) != 3 {
+		t.Fatalf("Expected 3 attempts but got %d", attempts)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, body := range bodies {
+		if body != "{\"name\":\"ok\"}\n" {
+			t.Fatalf("Expected JSON body to be re-sent on attempt %d but got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := retryTestClient(t, server.URL)
+
+	req, err := c.NewRequestContext(context.Background(), POST, server.URL, map[string]string{"name": "ok"})
+	if err != nil {
+		t.Fatalf("Expected request to build but got: %v", err)
+	}
+
+	c.DoRequest(req, nil)
+
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Fatalf("Expected POST to be attempted once but got %d attempts", attempts)
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := retryTestClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := c.NewRequestContext(ctx, GET, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected request to build but got: %v", err)
+	}
+
+	start := time.Now()
+	_, err = c.DoRequest(req, nil)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("Expected context deadline while honoring Retry-After but got: %v", err)
+	}
+
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("Expected wait to be interrupted by context")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var testSuite = []struct {
+		name   string
+		header string
+		wait   time.Duration
+		ok     bool
+	}{
+		{name: "absent", header: "", ok: false},
+		{name: "seconds", header: "7", wait: 7 * time.Second, ok: true},
+		{name: "past date", header: "Mon, 02 Jan 2006 15:04:05 GMT", wait: 0, ok: true},
+		{name: "garbage", header: "soon", ok: false},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			wait, ok := retryAfter(resp)
+			if ok != tt.ok || wait != tt.wait {
+				t.Fatalf("Expected (%v, %v) but got (%v, %v)", tt.wait, tt.ok, wait, ok)
+			}
+		})
+	}
+}
+
+func TestRetryBackoffBounds(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for retry := 1; retry <= 10; retry++ {
+		d := p.backoff(retry)
+		if d < 50*time.Millisecond || d > time.Second {
+			t.Fatalf("Expected backoff for retry %d within bounds but got %v", retry, d)
+		}
+	}
+}
+
+func TestRetryBackoffDefaults(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5}
+	for retry := 1; retry <= 10; retry++ {
+		d := p.backoff(retry)
+		if d < DefaultMinBackoff/2 || d > DefaultMaxBackoff {
+			t.Fatalf("Expected zero backoff fields to fall back to defaults but retry %d waits %v", retry, d)
+		}
+	}
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	var testSuite = []struct {
+		name      string
+		policy    *RetryPolicy
+		requested time.Duration
+		wait      time.Duration
+	}{
+		{name: "max retry after", policy: &RetryPolicy{MaxBackoff: time.Second, MaxRetryAfter: time.Minute}, requested: 24 * time.Hour, wait: time.Minute},
+		{name: "falls back to default", policy: &RetryPolicy{MaxBackoff: time.Second}, requested: 24 * time.Hour, wait: DefaultMaxRetryAfter},
+		{name: "zero policy", policy: &RetryPolicy{}, requested: 24 * time.Hour, wait: DefaultMaxRetryAfter},
+		{name: "below cap", policy: &RetryPolicy{MaxBackoff: time.Second, MaxRetryAfter: time.Minute}, requested: 7 * time.Second, wait: 7 * time.Second},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			if wait := tt.policy.capRetryAfter(tt.requested); wait != tt.wait {
+				t.Fatalf("Expected Retry-After of %v to wait %v but got %v", tt.requested, tt.wait, wait)
+			}
+		})
+	}
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	var testSuite = []struct {
+		name     string
+		err      error
+		attempts int32
+	}{
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, attempts: 3},
+		{name: "timeout", err: &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}, attempts: 3},
+		{name: "unknown authority", err: x509.UnknownAuthorityError{}, attempts: 1},
+		{name: "no such host", err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, attempts: 1},
+		{name: "other", err: fmt.Errorf("tls: handshake failure"), attempts: 1},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			c, err := NewClientWithOptions(
+				WithApplicationID(TestID),
+				WithAuthorizationHeaderToken(TestToken),
+				WithTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&attempts, 1)
+					return nil, tt.err
+				})),
+				WithRetryPolicy(&RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+			)
+			if err != nil {
+				t.Fatalf("Expected client to configure successfully but got: %v", err)
+			}
+
+			req, err := c.NewRequestContext(context.Background(), GET, "http://example.com", nil)
+			if err != nil {
+				t.Fatalf("Expected request to build but got: %v", err)
+			}
+			if _, err = c.DoRequest(req, nil); err == nil {
+				t.Fatalf("Expected transport error to be returned")
+			}
+			if n := atomic.LoadInt32(&attempts); n != tt.attempts {
+				t.Fatalf("Expected %d attempts but got %d", tt.attempts, n)
+			}
+		})
+	}
+}
@@ -15,11 +15,14 @@ import (
 POST /api/v1/accounts/{account_hash}/users/{user_id}/tokens
 GET /api/v1/accounts/{account_hash}/users/{user_id}/tokens
 DELETE /api/v1/accounts/{account_hash}/users/{user_id}/tokens/{token_id}
-NOT IMPLEMENTING POST /auth/token
+POST /auth/token is handled by striketracker.PasswordTokenSource
 */
 
 const path = "/tokens"
 
+// Service satisfies services.Tokens so consumers can substitute mocks
+var _ services.Tokens = (*Service)(nil)
+
 // Service describes the interaction with the auth API
 type Service struct {
 	client   *striketracker.Client
@@ -29,10 +32,7 @@ type Service struct {
 // New returns a new Auth Service
 func New(c *striketracker.Client) *Service {
 	e := &AuthEndpoint{
-		&endpoints.Endpoint{
-			BasePath: endpoints.ACCOUNTS,
-			Path:     path,
-		},
+		c.NewEndpoint(endpoints.Authentication, path),
 	}
 
 	return &Service{
@@ -48,7 +48,6 @@ func New(c *striketracker.Client) *Service {
 // Sends AccountHash, UserID, APITokenRequest
 // Receives Authentication
 func (s *Service) Create(ctx context.Context, accountHash string, userID string, password string, application string) (*models.Authentication, error) {
-
 	payload := &models.CreateTokenRequest{
 		AccountHash: accountHash,
 		UserID:      userID,
@@ -60,17 +59,7 @@ func (s *Service) Create(ctx context.Context, accountHash string, userID string,
 
 	answer := &models.Authentication{}
 
-	req, err := s.client.NewRequestContext(ctx, striketracker.POST, s.Endpoint.formatUser(accountHash, userID), payload)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.DoRequest(req, answer)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
+	if _, err := s.client.Call(ctx, striketracker.POST, s.Endpoint.formatUser(accountHash, userID), nil, payload, answer); err != nil {
 		return nil, err
 	}
 
@@ -28,5 +28,5 @@ func (a *AuthEndpoint) formatToken(accountHash string, userID string, token stri
 
 func (a *AuthEndpoint) formatAuth() string {
 	// This is a unique endpoint not following structure
-	return fmt.Sprintf("%s%s", endpoints.URL, AuthenticateEndpoint)
+	return fmt.Sprintf("%s%s", a.Root(), AuthenticateEndpoint)
 }
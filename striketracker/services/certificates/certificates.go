@@ -19,7 +19,7 @@ package certificates
 
 import (
 	"context"
-	"fmt"
+	"strconv"
 
 	"github.com/openwurl/wurlwind/striketracker"
 	"github.com/openwurl/wurlwind/striketracker/endpoints"
@@ -29,6 +29,9 @@ import (
 
 const path = "/certificates"
 
+// Service satisfies services.Certificates so consumers can substitute mocks
+var _ services.Certificates = (*Service)(nil)
+
 // Service describes the interaction with the certificates API
 type Service struct {
 	client   *striketracker.Client
@@ -37,10 +40,7 @@ type Service struct {
 
 // New returns a new Certificates Service
 func New(c *striketracker.Client) *Service {
-	e := &endpoints.Endpoint{
-		BasePath: endpoints.Accounts,
-		Path:     path,
-	}
+	e := c.NewEndpoint(endpoints.Certificates, path)
 
 	return &Service{
 		Endpoint: e,
@@ -57,17 +57,7 @@ func New(c *striketracker.Client) *Service {
 func (s *Service) List(ctx context.Context, accountHash string) (*models.CertificateResponse, error) {
 	cl := &models.CertificateResponse{}
 
-	req, err := s.client.NewRequestContext(ctx, striketracker.GET, s.Endpoint.Format(accountHash), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.DoRequest(req, cl)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Format(accountHash), nil, nil, cl); err != nil {
 		return nil, err
 	}
 
@@ -82,30 +72,9 @@ func (s *Service) List(ctx context.Context, accountHash string) (*models.Certifi
 //
 // Returns models.Certificate
 func (s *Service) Get(ctx context.Context, accountHash string, certificateID int) (*models.Certificate, error) {
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), certificateID)
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.GET, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	certificate := &models.Certificate{}
 
-	resp, err := s.client.DoRequest(req, certificate)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-
-		// Catch any embedded errors in the body that supercede
-		// validation errors and add them to our response
-		// This is difficult to make more generic and needs copied since
-		// Response is inherited and not first class in the struct
-		if respErr := certificate.Error(); respErr != nil {
-			err = respErr
-		}
-
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Segments(accountHash, strconv.Itoa(certificateID)), nil, nil, certificate); err != nil {
 		return nil, err
 	}
 
@@ -119,28 +88,17 @@ func (s *Service) Get(ctx context.Context, accountHash string, certificateID int
 // Receives models.CertificateHosts
 //
 // This is a weird one without the usually structured response
-// so there is no error extraction
+// so only the HTTP status is validated
 // It may need expanded once I come across a case with more than one Common Name
 func (s *Service) Hosts(ctx context.Context, accountHash string, certificateID int) (*models.CertificateHosts, error) {
-	endpoint := fmt.Sprintf("%s/%d/hosts", s.Endpoint.Format(accountHash), certificateID)
-	req, err := s.client.NewRequestContext(ctx, striketracker.GET, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	certHostsResponse := &models.CertificateHostsResponse{}
 
-	_, err = s.client.DoRequest(req, certHostsResponse)
-	if err != nil {
+	endpoint := s.Endpoint.Segments(accountHash, strconv.Itoa(certificateID), "hosts")
+	if _, err := s.client.Call(ctx, striketracker.GET, endpoint, nil, nil, certHostsResponse); err != nil {
 		return nil, err
 	}
 
-	certHosts, err := certHostsResponse.Process()
-	if err != nil {
-		return nil, err
-	}
-
-	return certHosts, nil
+	return certHostsResponse.Process()
 }
 
 // Upload a new certificate
@@ -151,27 +109,7 @@ func (s *Service) Hosts(ctx context.Context, accountHash string, certificateID i
 //
 // Returns models.Certificate
 func (s *Service) Upload(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error) {
-	if err := certificate.Validate(); err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.POST, s.Endpoint.Format(accountHash), certificate)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.DoRequest(req, certificate)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-
-		// Catch any embedded errors in the body and add them to our response
-		if respErr := certificate.Error(); respErr != nil {
-			err = respErr
-		}
-
+	if _, err := s.client.Call(ctx, striketracker.POST, s.Endpoint.Format(accountHash), nil, certificate, certificate); err != nil {
 		return nil, err
 	}
 
@@ -184,24 +122,8 @@ func (s *Service) Upload(ctx context.Context, accountHash string, certificate *m
 //
 // Accepts Certificate ID
 func (s *Service) Delete(ctx context.Context, accountHash string, certificateID int) error {
-	// construct endpoint with certificateID
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), certificateID)
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.DELETE, endpoint, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := s.client.DoRequest(req, nil)
-	if err != nil {
-		return err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-		return err
-	}
-
-	return nil
+	_, err := s.client.Call(ctx, striketracker.DELETE, s.Endpoint.Segments(accountHash, strconv.Itoa(certificateID)), nil, nil, nil)
+	return err
 }
 
 // Update an existing certificate
@@ -212,36 +134,41 @@ func (s *Service) Delete(ctx context.Context, accountHash string, certificateID
 //
 // Returns updated models.Certificate
 func (s *Service) Update(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error) {
-	// Validate incoming payload
-	if err := certificate.Validate(); err != nil {
-		return nil, err
-	}
+	endpoint := s.Endpoint.Segments(accountHash, strconv.Itoa(certificate.ID))
 
-	// Construct
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), certificate.ID)
-
-	// Build
-	req, err := s.client.NewRequestContext(ctx, striketracker.PUT, endpoint, certificate)
-	if err != nil {
-		return nil, err
-	}
-
-	// Execute
-	resp, err := s.client.DoRequest(req, certificate)
-	if err != nil {
+	if _, err := s.client.Call(ctx, striketracker.PUT, endpoint, nil, certificate, certificate); err != nil {
 		return nil, err
 	}
 
-	// Validate response
-	if err = services.ValidateResponse(resp); err != nil {
+	return certificate, nil
+}
 
-		// Catch any embedded errors in the body and add them to our response
-		if respErr := certificate.Error(); respErr != nil {
-			err = respErr
+// UploadBatch uploads certificates with up to workers requests at a time
+//
+// Every certificate is attempted, the report holds the uploaded
+// *models.Certificate or the error for each in the order given
+func (s *Service) UploadBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport {
+	ops := make([]striketracker.BatchOperation, len(certificates))
+	for i, certificate := range certificates {
+		certificate := certificate
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			return s.Upload(ctx, accountHash, certificate)
 		}
-
-		return nil, err
 	}
+	return striketracker.RunBatch(ctx, workers, ops)
+}
 
-	return certificate, nil
+// UpdateBatch updates certificates with up to workers requests at a time
+//
+// Every certificate is attempted, the report holds the updated
+// *models.Certificate or the error for each in the order given
+func (s *Service) UpdateBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport {
+	ops := make([]striketracker.BatchOperation, len(certificates))
+	for i, certificate := range certificates {
+		certificate := certificate
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			return s.Update(ctx, accountHash, certificate)
+		}
+	}
+	return striketracker.RunBatch(ctx, workers, ops)
 }
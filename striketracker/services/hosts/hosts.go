@@ -1,2 +1,110 @@
 // Package hosts describes the interactions with the striketracker Hosts service
+//  c, err := striketracker.NewClientWithOptions(
+//  	striketracker.WithApplicationID("DescriptiveApplicationName"),
+//  	striketracker.WithAuthorizationHeaderToken(authToken),
+//  )
+//  hostService := hosts.New(c)
+//
+//  list, err := hostService.List(ctx, accountHash)
 package hosts
+
+import (
+	"context"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+const path = "/hosts"
+
+// Service satisfies services.Hosts so consumers can substitute mocks
+var _ services.Hosts = (*Service)(nil)
+
+// Service describes the interaction with the hosts API
+// and contains the instantiated client
+type Service struct {
+	client   *striketracker.Client
+	Endpoint *endpoints.Endpoint
+}
+
+// New returns a new Hosts Service
+func New(c *striketracker.Client) *Service {
+	e := c.NewEndpoint(endpoints.Hosts, path)
+
+	return &Service{
+		Endpoint: e,
+		client:   c,
+	}
+}
+
+// List returns all hosts in the given account
+//
+// GET /api/v1/accounts/{account_hash}/hosts
+//
+// Returns models.HostList
+func (s *Service) List(ctx context.Context, accountHash string) (*models.HostList, error) {
+	hl := &models.HostList{}
+
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Format(accountHash), nil, nil, hl); err != nil {
+		return nil, err
+	}
+
+	return hl, nil
+}
+
+// Get a host
+//
+// GET /api/v1/accounts/{account_hash}/hosts/{host_hash}
+//
+// Returns models.Host
+func (s *Service) Get(ctx context.Context, accountHash string, hostHash string) (*models.Host, error) {
+	host := &models.Host{}
+
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Segments(accountHash, hostHash), nil, nil, host); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+// Create a new host
+//
+// POST /api/v1/accounts/{account_hash}/hosts
+//
+// Accepts a defined models.Host
+//
+// Returns the created models.Host with its hash code
+func (s *Service) Create(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error) {
+	if _, err := s.client.Call(ctx, striketracker.POST, s.Endpoint.Format(accountHash), nil, host, host); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+// Update a host
+//
+// PUT /api/v1/accounts/{account_hash}/hosts/{host_hash}
+//
+// Accepts models.Host
+//
+// Returns updated models.Host
+func (s *Service) Update(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error) {
+	if _, err := s.client.Call(ctx, striketracker.PUT, s.Endpoint.Segments(accountHash, host.HashCode), nil, host, host); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+// Delete a host
+//
+// DELETE /api/v1/accounts/{account_hash}/hosts/{host_hash}
+//
+// Returns error
+func (s *Service) Delete(ctx context.Context, accountHash string, hostHash string) error {
+	_, err := s.client.Call(ctx, striketracker.DELETE, s.Endpoint.Segments(accountHash, hostHash), nil, nil, nil)
+	return err
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+// Origins is satisfied by *origin.Service
+//
+// Depend on it rather than the concrete service so tests can substitute
+// mocks.Origins
+type Origins interface {
+	Create(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error)
+	Get(ctx context.Context, accountHash string, originID int) (*models.Origin, error)
+	Delete(ctx context.Context, accountHash string, originID int) error
+	Update(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error)
+	List(ctx context.Context, accountHash string) (*models.OriginList, error)
+	CreateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport
+	UpdateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport
+}
+
+// Certificates is satisfied by *certificates.Service
+type Certificates interface {
+	List(ctx context.Context, accountHash string) (*models.CertificateResponse, error)
+	Get(ctx context.Context, accountHash string, certificateID int) (*models.Certificate, error)
+	Hosts(ctx context.Context, accountHash string, certificateID int) (*models.CertificateHosts, error)
+	Upload(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error)
+	Delete(ctx context.Context, accountHash string, certificateID int) error
+	Update(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error)
+	UploadBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport
+	UpdateBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport
+}
+
+// Hosts is satisfied by *hosts.Service
+type Hosts interface {
+	List(ctx context.Context, accountHash string) (*models.HostList, error)
+	Get(ctx context.Context, accountHash string, hostHash string) (*models.Host, error)
+	Create(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error)
+	Update(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error)
+	Delete(ctx context.Context, accountHash string, hostHash string) error
+}
+
+// Tokens is satisfied by *authentication.Service
+type Tokens interface {
+	Create(ctx context.Context, accountHash string, userID string, password string, application string) (*models.Authentication, error)
+	List(ctx context.Context, accountHash string, userID string) (*models.AccessTokenList, error)
+	Delete(ctx context.Context, accountHash string, userID string, token string) error
+}
@@ -0,0 +1,296 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+// Certificates satisfies services.Certificates
+var _ services.Certificates = (*Certificates)(nil)
+
+// Certificates is a mock implementation of services.Certificates, standing in for *certificates.Service
+//
+// Each method records its arguments and returns the result of the
+// matching Func field, calling a method whose Func is nil panics
+//
+//  mock := &mocks.Certificates{
+//  	DeleteFunc: func(ctx context.Context, accountHash string, certificateID int) error {
+//  		return striketracker.ErrNotFound
+//  	},
+//  }
+//  // pass mock wherever services.Certificates is accepted
+//  calls := mock.DeleteCalls()
+type Certificates struct {
+	// ListFunc mocks the List method
+	ListFunc func(ctx context.Context, accountHash string) (*models.CertificateResponse, error)
+
+	// GetFunc mocks the Get method
+	GetFunc func(ctx context.Context, accountHash string, certificateID int) (*models.Certificate, error)
+
+	// HostsFunc mocks the Hosts method
+	HostsFunc func(ctx context.Context, accountHash string, certificateID int) (*models.CertificateHosts, error)
+
+	// UploadFunc mocks the Upload method
+	UploadFunc func(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error)
+
+	// DeleteFunc mocks the Delete method
+	DeleteFunc func(ctx context.Context, accountHash string, certificateID int) error
+
+	// UpdateFunc mocks the Update method
+	UpdateFunc func(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error)
+
+	// UploadBatchFunc mocks the UploadBatch method
+	UploadBatchFunc func(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport
+
+	// UpdateBatchFunc mocks the UpdateBatch method
+	UpdateBatchFunc func(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport
+
+	mu    sync.Mutex
+	calls struct {
+		List        []CertificatesListCall
+		Get         []CertificatesGetCall
+		Hosts       []CertificatesHostsCall
+		Upload      []CertificatesUploadCall
+		Delete      []CertificatesDeleteCall
+		Update      []CertificatesUpdateCall
+		UploadBatch []CertificatesUploadBatchCall
+		UpdateBatch []CertificatesUpdateBatchCall
+	}
+}
+
+// CertificatesListCall holds the arguments of a call to List
+type CertificatesListCall struct {
+	Ctx         context.Context
+	AccountHash string
+}
+
+// List calls ListFunc
+func (m *Certificates) List(ctx context.Context, accountHash string) (*models.CertificateResponse, error) {
+	m.mu.Lock()
+	m.calls.List = append(m.calls.List, CertificatesListCall{ctx, accountHash})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks.Certificates.ListFunc: method is nil but Certificates.List was just called")
+	}
+	return m.ListFunc(ctx, accountHash)
+}
+
+// ListCalls returns the calls made to List in order
+func (m *Certificates) ListCalls() []CertificatesListCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesListCall, len(m.calls.List))
+	copy(calls, m.calls.List)
+	return calls
+}
+
+// CertificatesGetCall holds the arguments of a call to Get
+type CertificatesGetCall struct {
+	Ctx           context.Context
+	AccountHash   string
+	CertificateID int
+}
+
+// Get calls GetFunc
+func (m *Certificates) Get(ctx context.Context, accountHash string, certificateID int) (*models.Certificate, error) {
+	m.mu.Lock()
+	m.calls.Get = append(m.calls.Get, CertificatesGetCall{ctx, accountHash, certificateID})
+	m.mu.Unlock()
+
+	if m.GetFunc == nil {
+		panic("mocks.Certificates.GetFunc: method is nil but Certificates.Get was just called")
+	}
+	return m.GetFunc(ctx, accountHash, certificateID)
+}
+
+// GetCalls returns the calls made to Get in order
+func (m *Certificates) GetCalls() []CertificatesGetCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesGetCall, len(m.calls.Get))
+	copy(calls, m.calls.Get)
+	return calls
+}
+
+// CertificatesHostsCall holds the arguments of a call to Hosts
+type CertificatesHostsCall struct {
+	Ctx           context.Context
+	AccountHash   string
+	CertificateID int
+}
+
+// Hosts calls HostsFunc
+func (m *Certificates) Hosts(ctx context.Context, accountHash string, certificateID int) (*models.CertificateHosts, error) {
+	m.mu.Lock()
+	m.calls.Hosts = append(m.calls.Hosts, CertificatesHostsCall{ctx, accountHash, certificateID})
+	m.mu.Unlock()
+
+	if m.HostsFunc == nil {
+		panic("mocks.Certificates.HostsFunc: method is nil but Certificates.Hosts was just called")
+	}
+	return m.HostsFunc(ctx, accountHash, certificateID)
+}
+
+// HostsCalls returns the calls made to Hosts in order
+func (m *Certificates) HostsCalls() []CertificatesHostsCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesHostsCall, len(m.calls.Hosts))
+	copy(calls, m.calls.Hosts)
+	return calls
+}
+
+// CertificatesUploadCall holds the arguments of a call to Upload
+type CertificatesUploadCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Certificate *models.Certificate
+}
+
+// Upload calls UploadFunc
+func (m *Certificates) Upload(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error) {
+	m.mu.Lock()
+	m.calls.Upload = append(m.calls.Upload, CertificatesUploadCall{ctx, accountHash, certificate})
+	m.mu.Unlock()
+
+	if m.UploadFunc == nil {
+		panic("mocks.Certificates.UploadFunc: method is nil but Certificates.Upload was just called")
+	}
+	return m.UploadFunc(ctx, accountHash, certificate)
+}
+
+// UploadCalls returns the calls made to Upload in order
+func (m *Certificates) UploadCalls() []CertificatesUploadCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesUploadCall, len(m.calls.Upload))
+	copy(calls, m.calls.Upload)
+	return calls
+}
+
+// CertificatesDeleteCall holds the arguments of a call to Delete
+type CertificatesDeleteCall struct {
+	Ctx           context.Context
+	AccountHash   string
+	CertificateID int
+}
+
+// Delete calls DeleteFunc
+func (m *Certificates) Delete(ctx context.Context, accountHash string, certificateID int) error {
+	m.mu.Lock()
+	m.calls.Delete = append(m.calls.Delete, CertificatesDeleteCall{ctx, accountHash, certificateID})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks.Certificates.DeleteFunc: method is nil but Certificates.Delete was just called")
+	}
+	return m.DeleteFunc(ctx, accountHash, certificateID)
+}
+
+// DeleteCalls returns the calls made to Delete in order
+func (m *Certificates) DeleteCalls() []CertificatesDeleteCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesDeleteCall, len(m.calls.Delete))
+	copy(calls, m.calls.Delete)
+	return calls
+}
+
+// CertificatesUpdateCall holds the arguments of a call to Update
+type CertificatesUpdateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Certificate *models.Certificate
+}
+
+// Update calls UpdateFunc
+func (m *Certificates) Update(ctx context.Context, accountHash string, certificate *models.Certificate) (*models.Certificate, error) {
+	m.mu.Lock()
+	m.calls.Update = append(m.calls.Update, CertificatesUpdateCall{ctx, accountHash, certificate})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks.Certificates.UpdateFunc: method is nil but Certificates.Update was just called")
+	}
+	return m.UpdateFunc(ctx, accountHash, certificate)
+}
+
+// UpdateCalls returns the calls made to Update in order
+func (m *Certificates) UpdateCalls() []CertificatesUpdateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesUpdateCall, len(m.calls.Update))
+	copy(calls, m.calls.Update)
+	return calls
+}
+
+// CertificatesUploadBatchCall holds the arguments of a call to UploadBatch
+type CertificatesUploadBatchCall struct {
+	Ctx          context.Context
+	AccountHash  string
+	Certificates []*models.Certificate
+	Workers      int
+}
+
+// UploadBatch calls UploadBatchFunc
+func (m *Certificates) UploadBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport {
+	m.mu.Lock()
+	m.calls.UploadBatch = append(m.calls.UploadBatch, CertificatesUploadBatchCall{ctx, accountHash, certificates, workers})
+	m.mu.Unlock()
+
+	if m.UploadBatchFunc == nil {
+		panic("mocks.Certificates.UploadBatchFunc: method is nil but Certificates.UploadBatch was just called")
+	}
+	return m.UploadBatchFunc(ctx, accountHash, certificates, workers)
+}
+
+// UploadBatchCalls returns the calls made to UploadBatch in order
+func (m *Certificates) UploadBatchCalls() []CertificatesUploadBatchCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesUploadBatchCall, len(m.calls.UploadBatch))
+	copy(calls, m.calls.UploadBatch)
+	return calls
+}
+
+// CertificatesUpdateBatchCall holds the arguments of a call to UpdateBatch
+type CertificatesUpdateBatchCall struct {
+	Ctx          context.Context
+	AccountHash  string
+	Certificates []*models.Certificate
+	Workers      int
+}
+
+// UpdateBatch calls UpdateBatchFunc
+func (m *Certificates) UpdateBatch(ctx context.Context, accountHash string, certificates []*models.Certificate, workers int) *striketracker.BatchReport {
+	m.mu.Lock()
+	m.calls.UpdateBatch = append(m.calls.UpdateBatch, CertificatesUpdateBatchCall{ctx, accountHash, certificates, workers})
+	m.mu.Unlock()
+
+	if m.UpdateBatchFunc == nil {
+		panic("mocks.Certificates.UpdateBatchFunc: method is nil but Certificates.UpdateBatch was just called")
+	}
+	return m.UpdateBatchFunc(ctx, accountHash, certificates, workers)
+}
+
+// UpdateBatchCalls returns the calls made to UpdateBatch in order
+func (m *Certificates) UpdateBatchCalls() []CertificatesUpdateBatchCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]CertificatesUpdateBatchCall, len(m.calls.UpdateBatch))
+	copy(calls, m.calls.UpdateBatch)
+	return calls
+}
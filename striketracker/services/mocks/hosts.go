@@ -0,0 +1,194 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+// Hosts satisfies services.Hosts
+var _ services.Hosts = (*Hosts)(nil)
+
+// Hosts is a mock implementation of services.Hosts, standing in for *hosts.Service
+//
+// Each method records its arguments and returns the result of the
+// matching Func field, calling a method whose Func is nil panics
+//
+//  mock := &mocks.Hosts{
+//  	ListFunc: func(ctx context.Context, accountHash string) (*models.HostList, error) {
+//  		return &models.HostList{}, nil
+//  	},
+//  }
+//  // pass mock wherever services.Hosts is accepted
+//  calls := mock.ListCalls()
+type Hosts struct {
+	// ListFunc mocks the List method
+	ListFunc func(ctx context.Context, accountHash string) (*models.HostList, error)
+
+	// GetFunc mocks the Get method
+	GetFunc func(ctx context.Context, accountHash string, hostHash string) (*models.Host, error)
+
+	// CreateFunc mocks the Create method
+	CreateFunc func(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error)
+
+	// UpdateFunc mocks the Update method
+	UpdateFunc func(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error)
+
+	// DeleteFunc mocks the Delete method
+	DeleteFunc func(ctx context.Context, accountHash string, hostHash string) error
+
+	mu    sync.Mutex
+	calls struct {
+		List   []HostsListCall
+		Get    []HostsGetCall
+		Create []HostsCreateCall
+		Update []HostsUpdateCall
+		Delete []HostsDeleteCall
+	}
+}
+
+// HostsListCall holds the arguments of a call to List
+type HostsListCall struct {
+	Ctx         context.Context
+	AccountHash string
+}
+
+// List calls ListFunc
+func (m *Hosts) List(ctx context.Context, accountHash string) (*models.HostList, error) {
+	m.mu.Lock()
+	m.calls.List = append(m.calls.List, HostsListCall{ctx, accountHash})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks.Hosts.ListFunc: method is nil but Hosts.List was just called")
+	}
+	return m.ListFunc(ctx, accountHash)
+}
+
+// ListCalls returns the calls made to List in order
+func (m *Hosts) ListCalls() []HostsListCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]HostsListCall, len(m.calls.List))
+	copy(calls, m.calls.List)
+	return calls
+}
+
+// HostsGetCall holds the arguments of a call to Get
+type HostsGetCall struct {
+	Ctx         context.Context
+	AccountHash string
+	HostHash    string
+}
+
+// Get calls GetFunc
+func (m *Hosts) Get(ctx context.Context, accountHash string, hostHash string) (*models.Host, error) {
+	m.mu.Lock()
+	m.calls.Get = append(m.calls.Get, HostsGetCall{ctx, accountHash, hostHash})
+	m.mu.Unlock()
+
+	if m.GetFunc == nil {
+		panic("mocks.Hosts.GetFunc: method is nil but Hosts.Get was just called")
+	}
+	return m.GetFunc(ctx, accountHash, hostHash)
+}
+
+// GetCalls returns the calls made to Get in order
+func (m *Hosts) GetCalls() []HostsGetCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]HostsGetCall, len(m.calls.Get))
+	copy(calls, m.calls.Get)
+	return calls
+}
+
+// HostsCreateCall holds the arguments of a call to Create
+type HostsCreateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Host        *models.Host
+}
+
+// Create calls CreateFunc
+func (m *Hosts) Create(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error) {
+	m.mu.Lock()
+	m.calls.Create = append(m.calls.Create, HostsCreateCall{ctx, accountHash, host})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks.Hosts.CreateFunc: method is nil but Hosts.Create was just called")
+	}
+	return m.CreateFunc(ctx, accountHash, host)
+}
+
+// CreateCalls returns the calls made to Create in order
+func (m *Hosts) CreateCalls() []HostsCreateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]HostsCreateCall, len(m.calls.Create))
+	copy(calls, m.calls.Create)
+	return calls
+}
+
+// HostsUpdateCall holds the arguments of a call to Update
+type HostsUpdateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Host        *models.Host
+}
+
+// Update calls UpdateFunc
+func (m *Hosts) Update(ctx context.Context, accountHash string, host *models.Host) (*models.Host, error) {
+	m.mu.Lock()
+	m.calls.Update = append(m.calls.Update, HostsUpdateCall{ctx, accountHash, host})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks.Hosts.UpdateFunc: method is nil but Hosts.Update was just called")
+	}
+	return m.UpdateFunc(ctx, accountHash, host)
+}
+
+// UpdateCalls returns the calls made to Update in order
+func (m *Hosts) UpdateCalls() []HostsUpdateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]HostsUpdateCall, len(m.calls.Update))
+	copy(calls, m.calls.Update)
+	return calls
+}
+
+// HostsDeleteCall holds the arguments of a call to Delete
+type HostsDeleteCall struct {
+	Ctx         context.Context
+	AccountHash string
+	HostHash    string
+}
+
+// Delete calls DeleteFunc
+func (m *Hosts) Delete(ctx context.Context, accountHash string, hostHash string) error {
+	m.mu.Lock()
+	m.calls.Delete = append(m.calls.Delete, HostsDeleteCall{ctx, accountHash, hostHash})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks.Hosts.DeleteFunc: method is nil but Hosts.Delete was just called")
+	}
+	return m.DeleteFunc(ctx, accountHash, hostHash)
+}
+
+// DeleteCalls returns the calls made to Delete in order
+func (m *Hosts) DeleteCalls() []HostsDeleteCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]HostsDeleteCall, len(m.calls.Delete))
+	copy(calls, m.calls.Delete)
+	return calls
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+// renameOrigin is consumer code depending on the interface
+func renameOrigin(ctx context.Context, origins services.Origins, accountHash string, id int, name string) error {
+	origin, err := origins.Get(ctx, accountHash, id)
+	if err != nil {
+		return err
+	}
+	origin.Name = name
+	_, err = origins.Update(ctx, accountHash, origin)
+	return err
+}
+
+func TestOriginsMock(t *testing.T) {
+	mock := &Origins{
+		GetFunc: func(ctx context.Context, accountHash string, originID int) (*models.Origin, error) {
+			return &models.Origin{ID: originID, Name: "before"}, nil
+		},
+		UpdateFunc: func(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error) {
+			return origin, nil
+		},
+	}
+
+	if err := renameOrigin(context.Background(), mock, "abc", 7, "after"); err != nil {
+		t.Fatalf("Expected rename to succeed but got: %v", err)
+	}
+
+	gets := mock.GetCalls()
+	if len(gets) != 1 || gets[0].AccountHash != "abc" || gets[0].OriginID != 7 {
+		t.Fatalf("Expected a single Get of origin 7 but got %+v", gets)
+	}
+	updates := mock.UpdateCalls()
+	if len(updates) != 1 || updates[0].Origin.Name != "after" {
+		t.Fatalf("Expected the renamed origin to be updated but got %+v", updates)
+	}
+
+	mock.GetFunc = func(ctx context.Context, accountHash string, originID int) (*models.Origin, error) {
+		return nil, striketracker.ErrNotFound
+	}
+	if err := renameOrigin(context.Background(), mock, "abc", 8, "after"); !errors.Is(err, striketracker.ErrNotFound) {
+		t.Fatalf("Expected the scripted error but got: %v", err)
+	}
+	if len(mock.UpdateCalls()) != 1 {
+		t.Fatalf("Expected no update after a failed get")
+	}
+}
+
+func TestUnscriptedMethodPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected calling a method without a Func to panic")
+		}
+	}()
+
+	mock := &Tokens{}
+	mock.Delete(context.Background(), "abc", "42", "token")
+}
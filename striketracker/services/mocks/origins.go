@@ -0,0 +1,265 @@
+// Package mocks provides mock implementations of the service interfaces in
+// striketracker/services which record calls and return scripted results
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+// Origins satisfies services.Origins
+var _ services.Origins = (*Origins)(nil)
+
+// Origins is a mock implementation of services.Origins, standing in for *origin.Service
+//
+// Each method records its arguments and returns the result of the
+// matching Func field, calling a method whose Func is nil panics
+//
+//  mock := &mocks.Origins{
+//  	GetFunc: func(ctx context.Context, accountHash string, originID int) (*models.Origin, error) {
+//  		return &models.Origin{ID: originID, Name: "mocked"}, nil
+//  	},
+//  }
+//  // pass mock wherever services.Origins is accepted
+//  calls := mock.GetCalls()
+type Origins struct {
+	// CreateFunc mocks the Create method
+	CreateFunc func(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error)
+
+	// GetFunc mocks the Get method
+	GetFunc func(ctx context.Context, accountHash string, originID int) (*models.Origin, error)
+
+	// DeleteFunc mocks the Delete method
+	DeleteFunc func(ctx context.Context, accountHash string, originID int) error
+
+	// UpdateFunc mocks the Update method
+	UpdateFunc func(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error)
+
+	// ListFunc mocks the List method
+	ListFunc func(ctx context.Context, accountHash string) (*models.OriginList, error)
+
+	// CreateBatchFunc mocks the CreateBatch method
+	CreateBatchFunc func(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport
+
+	// UpdateBatchFunc mocks the UpdateBatch method
+	UpdateBatchFunc func(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport
+
+	mu    sync.Mutex
+	calls struct {
+		Create      []OriginsCreateCall
+		Get         []OriginsGetCall
+		Delete      []OriginsDeleteCall
+		Update      []OriginsUpdateCall
+		List        []OriginsListCall
+		CreateBatch []OriginsCreateBatchCall
+		UpdateBatch []OriginsUpdateBatchCall
+	}
+}
+
+// OriginsCreateCall holds the arguments of a call to Create
+type OriginsCreateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Origin      *models.Origin
+}
+
+// Create calls CreateFunc
+func (m *Origins) Create(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error) {
+	m.mu.Lock()
+	m.calls.Create = append(m.calls.Create, OriginsCreateCall{ctx, accountHash, origin})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks.Origins.CreateFunc: method is nil but Origins.Create was just called")
+	}
+	return m.CreateFunc(ctx, accountHash, origin)
+}
+
+// CreateCalls returns the calls made to Create in order
+func (m *Origins) CreateCalls() []OriginsCreateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsCreateCall, len(m.calls.Create))
+	copy(calls, m.calls.Create)
+	return calls
+}
+
+// OriginsGetCall holds the arguments of a call to Get
+type OriginsGetCall struct {
+	Ctx         context.Context
+	AccountHash string
+	OriginID    int
+}
+
+// Get calls GetFunc
+func (m *Origins) Get(ctx context.Context, accountHash string, originID int) (*models.Origin, error) {
+	m.mu.Lock()
+	m.calls.Get = append(m.calls.Get, OriginsGetCall{ctx, accountHash, originID})
+	m.mu.Unlock()
+
+	if m.GetFunc == nil {
+		panic("mocks.Origins.GetFunc: method is nil but Origins.Get was just called")
+	}
+	return m.GetFunc(ctx, accountHash, originID)
+}
+
+// GetCalls returns the calls made to Get in order
+func (m *Origins) GetCalls() []OriginsGetCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsGetCall, len(m.calls.Get))
+	copy(calls, m.calls.Get)
+	return calls
+}
+
+// OriginsDeleteCall holds the arguments of a call to Delete
+type OriginsDeleteCall struct {
+	Ctx         context.Context
+	AccountHash string
+	OriginID    int
+}
+
+// Delete calls DeleteFunc
+func (m *Origins) Delete(ctx context.Context, accountHash string, originID int) error {
+	m.mu.Lock()
+	m.calls.Delete = append(m.calls.Delete, OriginsDeleteCall{ctx, accountHash, originID})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks.Origins.DeleteFunc: method is nil but Origins.Delete was just called")
+	}
+	return m.DeleteFunc(ctx, accountHash, originID)
+}
+
+// DeleteCalls returns the calls made to Delete in order
+func (m *Origins) DeleteCalls() []OriginsDeleteCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsDeleteCall, len(m.calls.Delete))
+	copy(calls, m.calls.Delete)
+	return calls
+}
+
+// OriginsUpdateCall holds the arguments of a call to Update
+type OriginsUpdateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Origin      *models.Origin
+}
+
+// Update calls UpdateFunc
+func (m *Origins) Update(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error) {
+	m.mu.Lock()
+	m.calls.Update = append(m.calls.Update, OriginsUpdateCall{ctx, accountHash, origin})
+	m.mu.Unlock()
+
+	if m.UpdateFunc == nil {
+		panic("mocks.Origins.UpdateFunc: method is nil but Origins.Update was just called")
+	}
+	return m.UpdateFunc(ctx, accountHash, origin)
+}
+
+// UpdateCalls returns the calls made to Update in order
+func (m *Origins) UpdateCalls() []OriginsUpdateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsUpdateCall, len(m.calls.Update))
+	copy(calls, m.calls.Update)
+	return calls
+}
+
+// OriginsListCall holds the arguments of a call to List
+type OriginsListCall struct {
+	Ctx         context.Context
+	AccountHash string
+}
+
+// List calls ListFunc
+func (m *Origins) List(ctx context.Context, accountHash string) (*models.OriginList, error) {
+	m.mu.Lock()
+	m.calls.List = append(m.calls.List, OriginsListCall{ctx, accountHash})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks.Origins.ListFunc: method is nil but Origins.List was just called")
+	}
+	return m.ListFunc(ctx, accountHash)
+}
+
+// ListCalls returns the calls made to List in order
+func (m *Origins) ListCalls() []OriginsListCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsListCall, len(m.calls.List))
+	copy(calls, m.calls.List)
+	return calls
+}
+
+// OriginsCreateBatchCall holds the arguments of a call to CreateBatch
+type OriginsCreateBatchCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Origins     []*models.Origin
+	Workers     int
+}
+
+// CreateBatch calls CreateBatchFunc
+func (m *Origins) CreateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport {
+	m.mu.Lock()
+	m.calls.CreateBatch = append(m.calls.CreateBatch, OriginsCreateBatchCall{ctx, accountHash, origins, workers})
+	m.mu.Unlock()
+
+	if m.CreateBatchFunc == nil {
+		panic("mocks.Origins.CreateBatchFunc: method is nil but Origins.CreateBatch was just called")
+	}
+	return m.CreateBatchFunc(ctx, accountHash, origins, workers)
+}
+
+// CreateBatchCalls returns the calls made to CreateBatch in order
+func (m *Origins) CreateBatchCalls() []OriginsCreateBatchCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsCreateBatchCall, len(m.calls.CreateBatch))
+	copy(calls, m.calls.CreateBatch)
+	return calls
+}
+
+// OriginsUpdateBatchCall holds the arguments of a call to UpdateBatch
+type OriginsUpdateBatchCall struct {
+	Ctx         context.Context
+	AccountHash string
+	Origins     []*models.Origin
+	Workers     int
+}
+
+// UpdateBatch calls UpdateBatchFunc
+func (m *Origins) UpdateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport {
+	m.mu.Lock()
+	m.calls.UpdateBatch = append(m.calls.UpdateBatch, OriginsUpdateBatchCall{ctx, accountHash, origins, workers})
+	m.mu.Unlock()
+
+	if m.UpdateBatchFunc == nil {
+		panic("mocks.Origins.UpdateBatchFunc: method is nil but Origins.UpdateBatch was just called")
+	}
+	return m.UpdateBatchFunc(ctx, accountHash, origins, workers)
+}
+
+// UpdateBatchCalls returns the calls made to UpdateBatch in order
+func (m *Origins) UpdateBatchCalls() []OriginsUpdateBatchCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]OriginsUpdateBatchCall, len(m.calls.UpdateBatch))
+	copy(calls, m.calls.UpdateBatch)
+	return calls
+}
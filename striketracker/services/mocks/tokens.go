@@ -0,0 +1,132 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services"
+)
+
+// Tokens satisfies services.Tokens
+var _ services.Tokens = (*Tokens)(nil)
+
+// Tokens is a mock implementation of services.Tokens, standing in for *authentication.Service
+//
+// Each method records its arguments and returns the result of the
+// matching Func field, calling a method whose Func is nil panics
+//
+//  mock := &mocks.Tokens{
+//  	CreateFunc: func(ctx context.Context, accountHash string, userID string, password string, application string) (*models.Authentication, error) {
+//  		return &models.Authentication{Token: "mocked"}, nil
+//  	},
+//  }
+//  // pass mock wherever services.Tokens is accepted
+//  calls := mock.CreateCalls()
+type Tokens struct {
+	// CreateFunc mocks the Create method
+	CreateFunc func(ctx context.Context, accountHash string, userID string, password string, application string) (*models.Authentication, error)
+
+	// ListFunc mocks the List method
+	ListFunc func(ctx context.Context, accountHash string, userID string) (*models.AccessTokenList, error)
+
+	// DeleteFunc mocks the Delete method
+	DeleteFunc func(ctx context.Context, accountHash string, userID string, token string) error
+
+	mu    sync.Mutex
+	calls struct {
+		Create []TokensCreateCall
+		List   []TokensListCall
+		Delete []TokensDeleteCall
+	}
+}
+
+// TokensCreateCall holds the arguments of a call to Create
+type TokensCreateCall struct {
+	Ctx         context.Context
+	AccountHash string
+	UserID      string
+	Password    string
+	Application string
+}
+
+// Create calls CreateFunc
+func (m *Tokens) Create(ctx context.Context, accountHash string, userID string, password string, application string) (*models.Authentication, error) {
+	m.mu.Lock()
+	m.calls.Create = append(m.calls.Create, TokensCreateCall{ctx, accountHash, userID, password, application})
+	m.mu.Unlock()
+
+	if m.CreateFunc == nil {
+		panic("mocks.Tokens.CreateFunc: method is nil but Tokens.Create was just called")
+	}
+	return m.CreateFunc(ctx, accountHash, userID, password, application)
+}
+
+// CreateCalls returns the calls made to Create in order
+func (m *Tokens) CreateCalls() []TokensCreateCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]TokensCreateCall, len(m.calls.Create))
+	copy(calls, m.calls.Create)
+	return calls
+}
+
+// TokensListCall holds the arguments of a call to List
+type TokensListCall struct {
+	Ctx         context.Context
+	AccountHash string
+	UserID      string
+}
+
+// List calls ListFunc
+func (m *Tokens) List(ctx context.Context, accountHash string, userID string) (*models.AccessTokenList, error) {
+	m.mu.Lock()
+	m.calls.List = append(m.calls.List, TokensListCall{ctx, accountHash, userID})
+	m.mu.Unlock()
+
+	if m.ListFunc == nil {
+		panic("mocks.Tokens.ListFunc: method is nil but Tokens.List was just called")
+	}
+	return m.ListFunc(ctx, accountHash, userID)
+}
+
+// ListCalls returns the calls made to List in order
+func (m *Tokens) ListCalls() []TokensListCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]TokensListCall, len(m.calls.List))
+	copy(calls, m.calls.List)
+	return calls
+}
+
+// TokensDeleteCall holds the arguments of a call to Delete
+type TokensDeleteCall struct {
+	Ctx         context.Context
+	AccountHash string
+	UserID      string
+	Token       string
+}
+
+// Delete calls DeleteFunc
+func (m *Tokens) Delete(ctx context.Context, accountHash string, userID string, token string) error {
+	m.mu.Lock()
+	m.calls.Delete = append(m.calls.Delete, TokensDeleteCall{ctx, accountHash, userID, token})
+	m.mu.Unlock()
+
+	if m.DeleteFunc == nil {
+		panic("mocks.Tokens.DeleteFunc: method is nil but Tokens.Delete was just called")
+	}
+	return m.DeleteFunc(ctx, accountHash, userID, token)
+}
+
+// DeleteCalls returns the calls made to Delete in order
+func (m *Tokens) DeleteCalls() []TokensDeleteCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]TokensDeleteCall, len(m.calls.Delete))
+	copy(calls, m.calls.Delete)
+	return calls
+}
@@ -18,7 +18,7 @@ package origin
 
 import (
 	"context"
-	"fmt"
+	"strconv"
 
 	"github.com/openwurl/wurlwind/striketracker"
 	"github.com/openwurl/wurlwind/striketracker/endpoints"
@@ -28,6 +28,9 @@ import (
 
 const path = "/origins"
 
+// Service satisfies services.Origins so consumers can substitute mocks
+var _ services.Origins = (*Service)(nil)
+
 // Service describes the interaction with the origins API
 // and contains the instantiated client
 type Service struct {
@@ -37,10 +40,7 @@ type Service struct {
 
 // New returns a new Origin Service
 func New(c *striketracker.Client) *Service {
-	e := &endpoints.Endpoint{
-		BasePath: endpoints.Origins,
-		Path:     path,
-	}
+	e := c.NewEndpoint(endpoints.Origins, path)
 
 	return &Service{
 		Endpoint: e,
@@ -56,28 +56,7 @@ func New(c *striketracker.Client) *Service {
 //
 // Returns an upodated models.Origin
 func (s *Service) Create(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error) {
-
-	if err := origin.Validate(); err != nil {
-		return nil, err
-	}
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.POST, s.Endpoint.Format(accountHash), origin)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.DoRequest(req, origin)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-
-		// Catch any embedded errors in the body and add them to our response
-		if respErr := origin.Error(); respErr != nil {
-			err = respErr
-		}
-
+	if _, err := s.client.Call(ctx, striketracker.POST, s.Endpoint.Format(accountHash), nil, origin, origin); err != nil {
 		return nil, err
 	}
 
@@ -102,30 +81,9 @@ func (s *Service) Create(ctx context.Context, accountHash string, origin *models
 //  receivedOrigin.Hostname = "new.hostname.com"
 //  updatedOrigin, err := o.Update(ctx, accountHash, receivedOrigin)
 func (s *Service) Get(ctx context.Context, accountHash string, originID int) (*models.Origin, error) {
-
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), originID)
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.GET, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
 	origin := &models.Origin{}
 
-	resp, err := s.client.DoRequest(req, origin)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-
-		// Catch any embedded errors in the body and add them to our response
-		// This is difficult to make more generic and needs copied since
-		// Response is inherited and not first class in the struct
-		if respErr := origin.Error(); respErr != nil {
-			err = respErr
-		}
-
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Segments(accountHash, strconv.Itoa(originID)), nil, nil, origin); err != nil {
 		return nil, err
 	}
 
@@ -140,25 +98,8 @@ func (s *Service) Get(ctx context.Context, accountHash string, originID int) (*m
 //
 // Returns error
 func (s *Service) Delete(ctx context.Context, accountHash string, originID int) error {
-
-	// Construct endpoint with originID
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), originID)
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.DELETE, endpoint, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := s.client.DoRequest(req, nil)
-	if err != nil {
-		return err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-		return err
-	}
-
-	return nil
+	_, err := s.client.Call(ctx, striketracker.DELETE, s.Endpoint.Segments(accountHash, strconv.Itoa(originID)), nil, nil, nil)
+	return err
 }
 
 // Update an origin
@@ -169,31 +110,9 @@ func (s *Service) Delete(ctx context.Context, accountHash string, originID int)
 //
 // Returns updated models.Origin
 func (s *Service) Update(ctx context.Context, accountHash string, origin *models.Origin) (*models.Origin, error) {
-	// Validate incoming origin payload
-	if err := origin.Validate(); err != nil {
-		return nil, err
-	}
-
-	// Construct endpoint with originID
-	endpoint := fmt.Sprintf("%s/%d", s.Endpoint.Format(accountHash), origin.ID)
-
-	req, err := s.client.NewRequestContext(ctx, striketracker.PUT, endpoint, origin)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.DoRequest(req, origin)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = services.ValidateResponse(resp); err != nil {
-
-		// Catch any embedded errors in the body and add them to our response
-		if respErr := origin.Error(); respErr != nil {
-			err = respErr
-		}
+	endpoint := s.Endpoint.Segments(accountHash, strconv.Itoa(origin.ID))
 
+	if _, err := s.client.Call(ctx, striketracker.PUT, endpoint, nil, origin, origin); err != nil {
 		return nil, err
 	}
 
@@ -206,22 +125,46 @@ func (s *Service) Update(ctx context.Context, accountHash string, origin *models
 //
 // Returns models.OriginList
 func (s *Service) List(ctx context.Context, accountHash string) (*models.OriginList, error) {
-
 	ol := &models.OriginList{}
 
-	req, err := s.client.NewRequestContext(ctx, striketracker.GET, s.Endpoint.Format(accountHash), nil)
-	if err != nil {
+	if _, err := s.client.Call(ctx, striketracker.GET, s.Endpoint.Format(accountHash), nil, nil, ol); err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.DoRequest(req, ol)
-	if err != nil {
-		return nil, err
-	}
+	return ol, nil
+}
 
-	if err = services.ValidateResponse(resp); err != nil {
-		return nil, err
+// CreateBatch creates origins with up to workers requests at a time
+//
+// Every origin is attempted, the report holds the created *models.Origin
+// or the error for each in the order given
+//
+//  report := o.CreateBatch(ctx, accountHash, origins, 8)
+//  for _, failed := range report.Failed() {
+//  	log.Printf("%s: %v", origins[failed.Index].Name, failed.Err)
+//  }
+func (s *Service) CreateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport {
+	ops := make([]striketracker.BatchOperation, len(origins))
+	for i, origin := range origins {
+		origin := origin
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			return s.Create(ctx, accountHash, origin)
+		}
 	}
+	return striketracker.RunBatch(ctx, workers, ops)
+}
 
-	return ol, nil
+// UpdateBatch updates origins with up to workers requests at a time
+//
+// Every origin is attempted, the report holds the updated *models.Origin
+// or the error for each in the order given
+func (s *Service) UpdateBatch(ctx context.Context, accountHash string, origins []*models.Origin, workers int) *striketracker.BatchReport {
+	ops := make([]striketracker.BatchOperation, len(origins))
+	for i, origin := range origins {
+		origin := origin
+		ops[i] = func(ctx context.Context) (interface{}, error) {
+			return s.Update(ctx, accountHash, origin)
+		}
+	}
+	return striketracker.RunBatch(ctx, workers, ops)
 }
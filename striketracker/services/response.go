@@ -1,14 +1,19 @@
 package services
 
 import (
-	"fmt"
 	"net/http"
+
+	"github.com/openwurl/wurlwind/striketracker"
 )
 
 // ValidateResponse HTTP response
+//
+// Returns a *striketracker.APIError carrying the status and URL on failure
+//
+// Services built on Client.Call receive validated responses and do not need this
 func ValidateResponse(resp *http.Response) error {
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("%s", resp.Status)
+		return striketracker.NewAPIError(resp, 0, "")
 	}
 	return nil
 }
@@ -0,0 +1,223 @@
+package striketracker
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownField is a response field with no counterpart in the model it was decoded into
+type UnknownField struct {
+	// Model is the Go type missing the field, such as models.Origin
+	Model string
+	// Field is the JSON key
+	Field string
+	// Path locates the field in the response, such as list[3].newField
+	Path string
+}
+
+// UnknownFieldsError lists the fields of a response which were dropped
+// while decoding, indicating the models have drifted from the API
+type UnknownFieldsError struct {
+	Method string
+	URL    string
+	Fields []UnknownField
+}
+
+// Error names each unrecognised field by model
+func (e *UnknownFieldsError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = fmt.Sprintf("%s.%s (%s)", f.Model, f.Field, f.Path)
+	}
+	return fmt.Sprintf("unknown fields in response to %s %s: %s", e.Method, e.URL, strings.Join(fields, ", "))
+}
+
+// UnknownFieldsHandler receives the unknown fields of each response
+type UnknownFieldsHandler func(report *UnknownFieldsError)
+
+// LogUnknownFields returns a handler reporting unknown fields to logger
+//
+//  striketracker.WithUnknownFieldsHandler(striketracker.LogUnknownFields(logger))
+func LogUnknownFields(logger Logger) UnknownFieldsHandler {
+	return func(report *UnknownFieldsError) {
+		for _, f := range report.Fields {
+			logger.Log("striketracker unknown field", Fields{
+				"method": report.Method,
+				"url":    report.URL,
+				"model":  f.Model,
+				"field":  f.Field,
+				"path":   f.Path,
+			})
+		}
+	}
+}
+
+// checkUnknownFields reports fields of a successful response body which v
+// has no place for, returning an error only in strict mode for GET and HEAD
+//
+// Mutations are never failed, as the caller would lose the created or
+// updated resource, instead they are reported to the UnknownFieldsHandler
+// or, without one, to the client logger. Dry-run responses echo the request
+// rather than the API and are never checked.
+func (c *Client) checkUnknownFields(req *http.Request, resp *http.Response, body []byte, v interface{}) error {
+	if !c.strictDecoding && c.unknownFields == nil {
+		return nil
+	}
+	if resp.Header.Get(DryRunHeader) != "" {
+		return nil
+	}
+	if v == nil || len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+
+	var fields []UnknownField
+	findUnknownFields(reflect.TypeOf(v), doc, "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	report := &UnknownFieldsError{Method: req.Method, URL: req.URL.String(), Fields: fields}
+	safe := req.Method == GET.String() || req.Method == HEAD.String()
+	switch {
+	case c.unknownFields != nil:
+		c.unknownFields(report)
+	case c.strictDecoding && !safe:
+		LogUnknownFields(c.logger)(report)
+	}
+	if c.strictDecoding && safe {
+		return report
+	}
+	return nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// findUnknownFields walks a decoded JSON document alongside the type it
+// was decoded into, collecting object keys encoding/json would ignore
+func findUnknownFields(t reflect.Type, doc interface{}, path string, fields *[]UnknownField) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		known := jsonFields(t)
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			field, ok := lookupField(known, key)
+			if !ok {
+				*fields = append(*fields, UnknownField{Model: t.String(), Field: key, Path: join(path, key)})
+				continue
+			}
+			findUnknownFields(field, object[key], join(path, key), fields)
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := doc.([]interface{})
+		if !ok {
+			return
+		}
+		for i, item := range list {
+			findUnknownFields(t.Elem(), item, fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	case reflect.Map:
+		object, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			findUnknownFields(t.Elem(), object[key], join(path, key), fields)
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's fields, including those
+// promoted from embedded structs such as models.Response, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	known := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					if _, ok := known[k]; !ok {
+						known[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		known[name] = f.Type
+	}
+	return known
+}
+
+// lookupField matches a key as encoding/json does, preferring an exact
+// match and otherwise ignoring case
+func lookupField(known map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := known[key]; ok {
+		return t, true
+	}
+	for name, t := range known {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// join appends a key to a document path
+func join(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
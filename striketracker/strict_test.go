@@ -0,0 +1,223 @@
+package striketracker_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services/origin"
+	"github.com/openwurl/wurlwind/striketracker/striketrackertest"
+)
+
+type driftEmbedded struct {
+	ID int `json:"id"`
+}
+
+type driftChild struct {
+	Path string `json:"path"`
+}
+
+type driftModel struct {
+	driftEmbedded
+	Name     string        `json:"name"`
+	Children []*driftChild `json:"children"`
+	Ignored  string        `json:"-"`
+}
+
+const driftBody = `{"id":1,"Name":"a","newField":true,"children":[{"path":"/"},{"path":"/b","weight":2}]}`
+
+func newDriftServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(driftBody))
+	}))
+}
+
+func TestUnknownFieldsLenientByDefault(t *testing.T) {
+	server := newDriftServer()
+	defer server.Close()
+
+	config := *striketracker.BaseConfiguration
+	c, err := striketracker.NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	out := &driftModel{}
+	if _, err = c.Call(context.Background(), striketracker.GET, server.URL+"/origins/1", nil, nil, out); err != nil {
+		t.Fatalf("Expected unknown fields to be ignored by default but got: %v", err)
+	}
+	if out.ID != 1 || out.Name != "a" || len(out.Children) != 2 {
+		t.Fatalf("Expected response to decode but got %+v", out)
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := newDriftServer()
+	defer server.Close()
+
+	config := *striketracker.BaseConfiguration
+	config.StrictDecoding = true
+	c, err := striketracker.NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	out := &driftModel{}
+	_, err = c.Call(context.Background(), striketracker.GET, server.URL+"/origins/1", nil, nil, out)
+
+	var unknown *striketracker.UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected an UnknownFieldsError but got: %v", err)
+	}
+	if out.Name != "a" || len(out.Children) != 2 {
+		t.Fatalf("Expected response to still decode but got %+v", out)
+	}
+
+	expected := []striketracker.UnknownField{
+		{Model: "striketracker_test.driftChild", Field: "weight", Path: "children[1].weight"},
+		{Model: "striketracker_test.driftModel", Field: "newField", Path: "newField"},
+	}
+	if len(unknown.Fields) != len(expected) {
+		t.Fatalf("Expected %d unknown fields but got %+v", len(expected), unknown.Fields)
+	}
+	for i := range expected {
+		if unknown.Fields[i] != expected[i] {
+			t.Fatalf("Expected %+v but got %+v", expected[i], unknown.Fields[i])
+		}
+	}
+}
+
+func TestUnknownFieldsHandler(t *testing.T) {
+	server := newDriftServer()
+	defer server.Close()
+
+	var logged []striketracker.Fields
+	config := *striketracker.BaseConfiguration
+	config.UnknownFieldsHandler = striketracker.LogUnknownFields(striketracker.LoggerFunc(func(msg string, fields striketracker.Fields) {
+		logged = append(logged, fields)
+	}))
+	c, err := striketracker.NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if _, err = c.Call(context.Background(), striketracker.GET, server.URL+"/origins/1", nil, nil, &driftModel{}); err != nil {
+		t.Fatalf("Expected handler to report without failing but got: %v", err)
+	}
+	if len(logged) != 2 {
+		t.Fatalf("Expected 2 unknown fields to be logged but got %v", logged)
+	}
+	if logged[1]["model"] != "striketracker_test.driftModel" || logged[1]["field"] != "newField" {
+		t.Fatalf("Expected newField on driftModel to be logged but got %v", logged[1])
+	}
+}
+
+func TestUnknownFieldsSkipDryRun(t *testing.T) {
+	var reported []*striketracker.UnknownFieldsError
+	config := *striketracker.BaseConfiguration
+	config.DryRun = true
+	config.StrictDecoding = true
+	config.UnknownFieldsHandler = func(report *striketracker.UnknownFieldsError) {
+		reported = append(reported, report)
+	}
+	c, err := striketracker.NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	// The echoed request body is not an API response
+	in := map[string]interface{}{"name": "a", "password": "hunter2"}
+	if _, err = c.Call(context.Background(), striketracker.POST, "http://example.com/origins", nil, in, &driftModel{}); err != nil {
+		t.Fatalf("Expected dry-run mutation to succeed but got: %v", err)
+	}
+	if len(reported) != 0 {
+		t.Fatalf("Expected dry-run responses to be skipped but got %+v", reported)
+	}
+}
+
+func TestUnknownFieldsMapOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"c":{"x":1},"a":{"x":1},"b":{"x":1}}`))
+	}))
+	defer server.Close()
+
+	config := *striketracker.BaseConfiguration
+	config.StrictDecoding = true
+	c, err := striketracker.NewClient(&config)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		var unknown *striketracker.UnknownFieldsError
+		_, err = c.Call(context.Background(), striketracker.GET, server.URL+"/origins", nil, nil, &map[string]*driftChild{})
+		if !errors.As(err, &unknown) {
+			t.Fatalf("Expected an UnknownFieldsError but got: %v", err)
+		}
+		if f := unknown.Fields; len(f) != 3 || f[0].Path != "a.x" || f[1].Path != "b.x" || f[2].Path != "c.x" {
+			t.Fatalf("Expected map fields sorted by key but got %+v", f)
+		}
+	}
+}
+
+// driftTransport adds a field unknown to the models to every JSON object response
+type driftTransport struct{}
+
+func (driftTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(body, []byte("{")) {
+		body = append([]byte(`{"newField":true,`), body[1:]...)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+func TestStrictDecodingMutations(t *testing.T) {
+	s := striketrackertest.NewServer()
+	defer s.Close()
+
+	var reported []*striketracker.UnknownFieldsError
+	c, err := s.Client(
+		striketracker.WithTransport(driftTransport{}),
+		striketracker.WithStrictDecoding(true),
+		striketracker.WithUnknownFieldsHandler(func(report *striketracker.UnknownFieldsError) {
+			reported = append(reported, report)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	o := origin.New(c)
+	ctx := context.Background()
+
+	created, err := o.Create(ctx, striketrackertest.AccountHash, &models.Origin{Name: "drift", Hostname: "drift.example.com", Port: 80})
+	if err != nil {
+		t.Fatalf("Expected a strict create to succeed despite unknown fields but got: %v", err)
+	}
+	if created == nil || created.ID == 0 {
+		t.Fatalf("Expected the created origin to be returned but got %+v", created)
+	}
+	if len(reported) != 1 || reported[0].Method != http.MethodPost || reported[0].Fields[0].Field != "newField" {
+		t.Fatalf("Expected the create's unknown fields to be reported but got %+v", reported)
+	}
+
+	var unknown *striketracker.UnknownFieldsError
+	if _, err = o.Get(ctx, striketrackertest.AccountHash, created.ID); !errors.As(err, &unknown) {
+		t.Fatalf("Expected a strict get to fail with an UnknownFieldsError but got: %v", err)
+	}
+}
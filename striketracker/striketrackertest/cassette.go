@@ -0,0 +1,327 @@
+package striketrackertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/openwurl/wurlwind/striketracker"
+)
+
+// RecordEnv selects ModeRecord for ModeFromEnv when set to a non-empty value
+const RecordEnv = "STRIKETRACKER_RECORD"
+
+// Mode selects whether a Recorder captures live traffic or replays a cassette
+type Mode int
+
+// Recorder modes
+const (
+	// ModeReplay answers requests from the cassette without any network access
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests and saves each interaction to the cassette
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord when RecordEnv is set, otherwise ModeReplay,
+// so CI always replays while fixtures are refreshed with
+//  STRIKETRACKER_RECORD=1 go test ./...
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Cassette is the fixture file holding recorded interactions in order
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a scrubbed request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a scrubbed response
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// UnexpectedRequestError is returned in replay mode for a request which
+// matches no unplayed interaction in the cassette
+type UnexpectedRequestError struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// Error describes the unmatched request
+func (e *UnexpectedRequestError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected request %s %s not found in cassette", e.Method, e.Path)
+	}
+	return fmt.Sprintf("unexpected request %s %s with body %s not found in cassette", e.Method, e.Path, e.Body)
+}
+
+// Recorder is an http.RoundTripper which records interactions to, or
+// replays them from, a cassette file
+//
+// Bearer tokens and sensitive body fields such as certificate keys,
+// passwords and access tokens are replaced with striketracker.Redacted
+// before anything is written. Replayed requests are matched by method,
+// path, query and scrubbed body, each interaction is played at most once.
+//
+//  r, err := striketrackertest.NewRecorder("testdata/origins.json", striketrackertest.ModeFromEnv(), nil)
+//  defer r.Stop()
+//
+//  c, err := striketracker.NewClientWithOptions(
+//  	// ...
+//  	striketracker.WithTransport(r),
+//  )
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	played   []bool
+}
+
+// NewRecorder returns a recorder for the cassette at path
+//
+// In ModeRecord requests are sent through transport, defaulting to
+// striketracker.NewTransport, and the cassette is written by Stop.
+// In ModeReplay the cassette must exist.
+func NewRecorder(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+		cassette:  &Cassette{},
+	}
+
+	if mode == ModeRecord {
+		if r.transport == nil {
+			r.transport = striketracker.NewTransport()
+		}
+		return r, nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err = json.Unmarshal(contents, r.cassette); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.played = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// RoundTrip records or replays a single request
+//
+// The caller's request is never modified, its body is read from GetBody
+// when available and otherwise sent on from a buffered clone
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req
+	body, err := copyRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		out = req.Clone(req.Context())
+		out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: scrubHeader(req.Header),
+		Body:   string(scrubBody(req.Header.Get("Content-Type"), body)),
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(out, recorded)
+	}
+	if req.Body != nil && req.GetBody != nil {
+		// Transports must close the body even when it is not sent
+		req.Body.Close()
+	}
+	return r.replay(req, recorded)
+}
+
+// copyRequestBody reads a copy of the body from GetBody, or consumes and
+// closes the body when it cannot be copied
+func copyRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	rc := req.Body
+	if req.GetBody != nil {
+		var err error
+		if rc, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+// record sends the request and appends the scrubbed interaction
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       string(striketracker.RedactJSON(body)),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay answers the request from the first unplayed matching interaction
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.played[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.played[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &UnexpectedRequestError{Method: req.Method, Path: pathOf(recorded.URL), Body: recorded.Body}
+}
+
+// Unplayed returns the interactions not yet replayed, so tests can assert
+// every recorded call was made, it is always empty when recording
+func (r *Recorder) Unplayed() []*Interaction {
+	if r.mode == ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []*Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.played[i] {
+			out = append(out, interaction)
+		}
+	}
+	return out
+}
+
+// Stop writes the cassette in record mode, it does nothing when replaying
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, contents, 0644)
+}
+
+// matches compares method, path, query and scrubbed body
+func matches(recorded RecordedRequest, req RecordedRequest) bool {
+	return recorded.Method == req.Method && pathOf(recorded.URL) == pathOf(req.URL) && recorded.Body == req.Body
+}
+
+// pathOf strips the scheme and host so cassettes replay against any base
+// URL, keeping the query with its parameters sorted
+func pathOf(URL string) string {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return URL
+	}
+	if query := u.Query().Encode(); query != "" {
+		return u.Path + "?" + query
+	}
+	return u.Path
+}
+
+// scrubHeader removes credentials from request headers
+func scrubHeader(h http.Header) http.Header {
+	out := h.Clone()
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer "+striketracker.Redacted)
+	}
+	out.Del("Cookie")
+	return out
+}
+
+// scrubBody redacts sensitive fields of a form or JSON request body
+func scrubBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return striketracker.RedactForm(body)
+	}
+	return striketracker.RedactJSON(body)
+}
@@ -0,0 +1,192 @@
+package striketrackertest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services/certificates"
+	"github.com/openwurl/wurlwind/striketracker/services/origin"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "striketrackertest")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "session.json")
+
+	cert, key, err := GenerateCertificate("cassette.example.com")
+	if err != nil {
+		t.Fatalf("Expected a generated certificate but got: %v", err)
+	}
+	newOrigin := func() *models.Origin {
+		return &models.Origin{Name: "cassette", Hostname: "cassette.example.com", Port: 80}
+	}
+	ctx := context.Background()
+
+	// Record a session against the fake server
+	s := NewServer()
+	recorder, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Expected recorder but got: %v", err)
+	}
+	c, err := s.Client(striketracker.WithTransport(recorder))
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	created, err := origin.New(c).Create(ctx, AccountHash, newOrigin())
+	if err != nil {
+		t.Fatalf("Expected origin to be created while recording but got: %v", err)
+	}
+	if _, err = certificates.New(c).Upload(ctx, AccountHash, &models.Certificate{Certificate: cert, Key: key}); err != nil {
+		t.Fatalf("Expected certificate to be uploaded while recording but got: %v", err)
+	}
+	if err = recorder.Stop(); err != nil {
+		t.Fatalf("Expected cassette to be written but got: %v", err)
+	}
+	s.Close()
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected cassette file but got: %v", err)
+	}
+	for _, secret := range []string{Token, strings.Split(key, "\n")[1]} {
+		if strings.Contains(string(contents), secret) {
+			t.Fatalf("Expected %s to be scrubbed from the cassette", secret)
+		}
+	}
+
+	// Replay it with no server running
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Expected cassette to load but got: %v", err)
+	}
+	c, err = striketracker.NewClientWithOptions(
+		striketracker.WithApplicationID(ApplicationID),
+		striketracker.WithAuthorizationHeaderToken("another-token"),
+		striketracker.WithBaseURL("https://replay.invalid"),
+		striketracker.WithTransport(replayer),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	replayed, err := origin.New(c).Create(ctx, AccountHash, newOrigin())
+	if err != nil {
+		t.Fatalf("Expected origin create to replay but got: %v", err)
+	}
+	if replayed.ID != created.ID {
+		t.Fatalf("Expected the recorded origin ID %d but got %d", created.ID, replayed.ID)
+	}
+	if len(replayer.Unplayed()) != 1 {
+		t.Fatalf("Expected the certificate upload to remain unplayed but got %d", len(replayer.Unplayed()))
+	}
+
+	// Each interaction plays once and other requests fail
+	var unexpected *UnexpectedRequestError
+	if _, err = origin.New(c).Create(ctx, AccountHash, newOrigin()); !errors.As(err, &unexpected) {
+		t.Fatalf("Expected a replayed interaction not to match twice but got: %v", err)
+	}
+	if _, err = origin.New(c).List(ctx, AccountHash); !errors.As(err, &unexpected) || unexpected.Method != "GET" {
+		t.Fatalf("Expected an unexpected request error for an unrecorded call but got: %v", err)
+	}
+
+	if _, err = NewRecorder(filepath.Join(dir, "missing.json"), ModeReplay, nil); err == nil {
+		t.Fatalf("Expected an error replaying a missing cassette")
+	}
+}
+
+func TestRecorderLeavesRequestUntouched(t *testing.T) {
+	dir, err := ioutil.TempDir("", "striketrackertest")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s := NewServer()
+	defer s.Close()
+
+	recorder, err := NewRecorder(filepath.Join(dir, "session.json"), ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Expected recorder but got: %v", err)
+	}
+
+	for _, getBody := range []bool{true, false} {
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/api/v1/accounts/"+AccountHash+"/origins", strings.NewReader(`{"name":"untouched","hostname":"untouched.example.com","port":80}`))
+		if err != nil {
+			t.Fatalf("Expected request to build but got: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+Token)
+		if !getBody {
+			req.GetBody = nil
+		}
+		body := req.Body
+
+		resp, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Expected request to be recorded but got: %v", err)
+		}
+		resp.Body.Close()
+		if req.Body != body {
+			t.Fatalf("Expected the caller's request body not to be replaced (GetBody %v)", getBody)
+		}
+	}
+
+	for i, interaction := range recorder.cassette.Interactions {
+		if !strings.Contains(interaction.Request.Body, "untouched") {
+			t.Fatalf("Expected request body %d to be recorded but got %q", i, interaction.Request.Body)
+		}
+	}
+}
+
+func TestRecorderMatchesQuery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "striketrackertest")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "session.json")
+
+	cassette := `{"interactions":[
+		{"request":{"method":"GET","url":"https://example.com/api/v1/accounts/abc/hosts?page=1&size=2"},"response":{"statusCode":200,"body":"first"}},
+		{"request":{"method":"GET","url":"https://example.com/api/v1/accounts/abc/hosts?page=2&size=2"},"response":{"statusCode":200,"body":"second"}}
+	]}`
+	if err = ioutil.WriteFile(path, []byte(cassette), 0644); err != nil {
+		t.Fatalf("Expected cassette to be written but got: %v", err)
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Expected cassette to load but got: %v", err)
+	}
+
+	for _, tt := range []struct{ query, body string }{{"size=2&page=2", "second"}, {"page=1&size=2", "first"}} {
+		req, err := http.NewRequest(http.MethodGet, "https://replay.invalid/api/v1/accounts/abc/hosts?"+tt.query, nil)
+		if err != nil {
+			t.Fatalf("Expected request to build but got: %v", err)
+		}
+		resp, err := replayer.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Expected ?%s to replay but got: %v", tt.query, err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != tt.body {
+			t.Fatalf("Expected ?%s to replay %q but got %q", tt.query, tt.body, body)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://replay.invalid/api/v1/accounts/abc/hosts?page=3", nil)
+	var unexpected *UnexpectedRequestError
+	if _, err = replayer.RoundTrip(req); !errors.As(err, &unexpected) || unexpected.Path != "/api/v1/accounts/abc/hosts?page=3" {
+		t.Fatalf("Expected an unrecorded query not to match but got: %v", err)
+	}
+}
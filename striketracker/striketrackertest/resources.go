@@ -0,0 +1,384 @@
+package striketrackertest
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+// TokenExpiresIn is the lifetime in seconds of tokens issued by POST /auth/token
+const TokenExpiresIn = 3600
+
+// readRequestBody reads the body, leaving a copy for form parsing
+func readRequestBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// decode unmarshals a JSON request body, writing a validation failure if it is malformed
+func decode(w http.ResponseWriter, body []byte, v interface{}) bool {
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return false
+	}
+	return true
+}
+
+// authenticate emulates the password and refresh token grants of POST /auth/token
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrMissingGrantType, "")
+		return
+	}
+
+	switch form.Get("grant_type") {
+	case "password":
+		if form.Get("username") != s.username || form.Get("password") != s.password {
+			writeError(w, http.StatusUnauthorized, striketracker.ErrUnauthenticated, "")
+			return
+		}
+	case "refresh_token":
+		if !s.refresh[form.Get("refresh_token")] {
+			writeError(w, http.StatusUnauthorized, striketracker.ErrUnauthenticated, "")
+			return
+		}
+		delete(s.refresh, form.Get("refresh_token"))
+	default:
+		writeError(w, http.StatusBadRequest, striketracker.ErrMissingGrantType, "")
+		return
+	}
+
+	token := &models.AuthToken{
+		AccessToken:  fmt.Sprintf("access-%d", s.id()),
+		RefreshToken: fmt.Sprintf("refresh-%d", s.id()),
+		ExpiresIn:    TokenExpiresIn,
+		TokenType:    "Bearer",
+		Application:  r.Header.Get("X-Application-Id"),
+		UserAgent:    r.UserAgent(),
+	}
+	s.issued[token.AccessToken] = true
+	s.refresh[token.RefreshToken] = true
+
+	writeJSON(w, http.StatusOK, token)
+}
+
+// serveOrigins emulates /accounts/{account_hash}/origins
+func (s *Server) serveOrigins(w http.ResponseWriter, method string, a *account, segments []string, body []byte) {
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, &models.OriginList{List: a.originList()})
+		case http.MethodPost:
+			origin := &models.Origin{}
+			if !decode(w, body, origin) || !s.validOrigin(w, a, origin) {
+				return
+			}
+			origin.ID = s.id()
+			origin.CreatedDate = s.timestamp()
+			origin.UpdatedDate = origin.CreatedDate
+			if origin.Type == "" {
+				origin.Type = "EXTERNAL"
+			}
+			a.origins[origin.ID] = origin
+			writeJSON(w, http.StatusOK, origin)
+		default:
+			writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(segments[0])
+	existing, ok := a.origins[id]
+	if err != nil || !ok || len(segments) > 1 {
+		writeError(w, http.StatusNotFound, striketracker.ErrNotFound, "")
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		origin := &models.Origin{}
+		if !decode(w, body, origin) || !s.validOrigin(w, a, origin, id) {
+			return
+		}
+		origin.ID = id
+		origin.CreatedDate = existing.CreatedDate
+		origin.UpdatedDate = s.timestamp()
+		if origin.Type == "" {
+			origin.Type = existing.Type
+		}
+		a.origins[id] = origin
+		writeJSON(w, http.StatusOK, origin)
+	case http.MethodDelete:
+		delete(a.origins, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+	}
+}
+
+// validOrigin validates the origin and rejects duplicates of any origin other than except
+func (s *Server) validOrigin(w http.ResponseWriter, a *account, origin *models.Origin, except ...int) bool {
+	if err := origin.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return false
+	}
+
+	for id, existing := range a.origins {
+		if len(except) > 0 && id == except[0] {
+			continue
+		}
+		if existing.Hostname == origin.Hostname && existing.Port == origin.Port && existing.Path == origin.Path {
+			writeError(w, http.StatusBadRequest, striketracker.ErrDuplicateOrigin, "")
+			return false
+		}
+	}
+	return true
+}
+
+// serveCertificates emulates /accounts/{account_hash}/certificates
+func (s *Server) serveCertificates(w http.ResponseWriter, method string, a *account, segments []string, body []byte) {
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, &models.CertificateResponse{List: a.certificateList()})
+		case http.MethodPost:
+			certificate := &models.Certificate{}
+			if !decode(w, body, certificate) || !s.validCertificate(w, certificate) {
+				return
+			}
+			certificate.ID = s.id()
+			certificate.CreatedDate = s.timestamp()
+			certificate.UpdatedDate = certificate.CreatedDate
+			a.certificates[certificate.ID] = certificate
+			writeJSON(w, http.StatusOK, certificate)
+		default:
+			writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(segments[0])
+	existing, ok := a.certificates[id]
+	if err != nil || !ok || len(segments) > 2 || (len(segments) == 2 && segments[1] != "hosts") {
+		writeError(w, http.StatusNotFound, striketracker.ErrNotFound, "")
+		return
+	}
+
+	if len(segments) == 2 {
+		if method != http.MethodGet {
+			writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+			return
+		}
+		writeJSON(w, http.StatusOK, models.CertificateHostsResponse{existing.CommonName: {}})
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		certificate := &models.Certificate{}
+		if !decode(w, body, certificate) || !s.validCertificate(w, certificate) {
+			return
+		}
+		certificate.ID = id
+		certificate.CreatedDate = existing.CreatedDate
+		certificate.UpdatedDate = s.timestamp()
+		a.certificates[id] = certificate
+		writeJSON(w, http.StatusOK, certificate)
+	case http.MethodDelete:
+		delete(a.certificates, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+	}
+}
+
+// validCertificate validates the certificate and fills the fields
+// Striketracker derives from the x.509 certificate
+func (s *Server) validCertificate(w http.ResponseWriter, certificate *models.Certificate) bool {
+	if err := certificate.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return false
+	}
+
+	block, _ := pem.Decode([]byte(certificate.Certificate))
+	if block == nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, "certificate is not PEM encoded")
+		return false
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return false
+	}
+
+	certificate.CommonName = parsed.Subject.CommonName
+	certificate.Issuer = parsed.Issuer.CommonName
+	certificate.ExpirationDate = parsed.NotAfter.UTC().Format("2006-01-02T15:04:05Z")
+	certificate.Fingerprint = fmt.Sprintf("%X", sha1.Sum(parsed.Raw))
+	certificate.CertificateInformation = &models.CertificateInformation{
+		Name:    parsed.Subject.String(),
+		Subject: &models.CertificateSubject{CN: parsed.Subject.CommonName},
+	}
+	return true
+}
+
+// serveHosts emulates /accounts/{account_hash}/hosts
+func (s *Server) serveHosts(w http.ResponseWriter, method string, a *account, segments []string, body []byte) {
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, &models.HostList{List: a.hostList()})
+		case http.MethodPost:
+			host := &models.Host{}
+			if !decode(w, body, host) || !validHost(w, host) {
+				return
+			}
+			host.HashCode = fmt.Sprintf("%010x", s.id())
+			host.CreatedDate = s.timestamp()
+			host.UpdatedDate = host.CreatedDate
+			if host.Type == "" {
+				host.Type = "HOST"
+			}
+			a.hosts[host.HashCode] = host
+			writeJSON(w, http.StatusOK, host)
+		default:
+			writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		}
+		return
+	}
+
+	existing, ok := a.hosts[segments[0]]
+	if !ok || len(segments) > 1 {
+		writeError(w, http.StatusNotFound, striketracker.ErrNotFound, "")
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		host := &models.Host{}
+		if !decode(w, body, host) || !validHost(w, host) {
+			return
+		}
+		host.HashCode = existing.HashCode
+		host.CreatedDate = existing.CreatedDate
+		host.UpdatedDate = s.timestamp()
+		if host.Type == "" {
+			host.Type = existing.Type
+		}
+		a.hosts[host.HashCode] = host
+		writeJSON(w, http.StatusOK, host)
+	case http.MethodDelete:
+		delete(a.hosts, existing.HashCode)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+	}
+}
+
+// validHost validates the host
+func validHost(w http.ResponseWriter, host *models.Host) bool {
+	if err := host.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return false
+	}
+	return true
+}
+
+// serveTokens emulates /accounts/{account_hash}/users/{user_id}/tokens
+func (s *Server) serveTokens(w http.ResponseWriter, r *http.Request, a *account, segments []string, body []byte) {
+	if len(segments) < 2 || len(segments) > 3 || segments[1] != "tokens" {
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		return
+	}
+
+	userID := segments[0]
+	tokens, ok := a.tokens[userID]
+	if !ok {
+		tokens = make(map[int]*models.AccessToken)
+		a.tokens[userID] = tokens
+	}
+
+	if len(segments) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string][]models.AccessToken{"list": a.tokenList(userID)})
+		case http.MethodPost:
+			// The SDK wraps the request in models.CreateTokenRequest
+			request := &models.CreateTokenRequest{}
+			if !decode(w, body, request) {
+				return
+			}
+			if request.APITokenRequest == nil {
+				request.APITokenRequest = &models.APITokenRequest{}
+				if !decode(w, body, request.APITokenRequest) {
+					return
+				}
+			}
+			if request.APITokenRequest.Password != s.password {
+				writeError(w, http.StatusUnauthorized, striketracker.ErrUnauthenticated, "")
+				return
+			}
+
+			token := &models.AccessToken{
+				Active:      true,
+				Application: request.APITokenRequest.Application,
+				ID:          s.id(),
+				IP:          strings.Split(r.RemoteAddr, ":")[0],
+			}
+			token.Token = fmt.Sprintf("api-token-%d", token.ID)
+			tokens[token.ID] = token
+			s.issued[token.Token] = true
+
+			writeJSON(w, http.StatusOK, &models.Authentication{
+				Application: token.Application,
+				IP:          token.IP,
+				Token:       token.Token,
+			})
+		default:
+			writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(segments[2])
+	token, ok := tokens[id]
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, striketracker.ErrNotFound, "")
+		return
+	}
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		return
+	}
+	delete(tokens, id)
+	delete(s.issued, token.Token)
+	w.WriteHeader(http.StatusNoContent)
+}
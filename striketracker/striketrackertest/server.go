@@ -0,0 +1,255 @@
+// Package striketrackertest provides an in-memory Striketracker API for
+// unit testing code built on the SDK without credentials or network access
+//
+//  s := striketrackertest.NewServer()
+//  defer s.Close()
+//
+//  c, err := s.Client()
+//  o := origin.New(c)
+//
+//  created, err := o.Create(ctx, striketrackertest.AccountHash, &models.Origin{...})
+//  origins := s.Origins(striketrackertest.AccountHash)
+//
+// Origins, certificates, hosts and user tokens are emulated with generated
+// IDs, model validation, ErrDuplicateOrigin and 404s, answering with the
+// same {error, code} envelope as Striketracker.
+package striketrackertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+// Defaults accepted by a new Server
+const (
+	Token         = "striketrackertest-token"
+	AccountHash   = "a1b2c3d4"
+	Username      = "striketrackertest"
+	Password      = "striketrackertest-password"
+	ApplicationID = "striketrackertest"
+)
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// failure is a scripted error response
+type failure struct {
+	status int
+	err    *striketracker.APIError
+}
+
+// account holds the resources of a single account
+type account struct {
+	origins      map[int]*models.Origin
+	certificates map[int]*models.Certificate
+	hosts        map[string]*models.Host
+	tokens       map[string]map[int]*models.AccessToken
+}
+
+// Server is an httptest server emulating the Striketracker API
+//
+// Any account hash is accepted and its resources are created on first use.
+// Requests must carry the bearer Token, or one issued by POST /auth/token
+// for Username and Password.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	token    string
+	username string
+	password string
+	issued   map[string]bool
+	refresh  map[string]bool
+	accounts map[string]*account
+	requests []Request
+	failures []failure
+	nextID   int
+	now      func() time.Time
+}
+
+// NewServer starts a server accepting Token
+func NewServer() *Server {
+	s := &Server{
+		token:    Token,
+		username: Username,
+		password: Password,
+		issued:   make(map[string]bool),
+		refresh:  make(map[string]bool),
+		accounts: make(map[string]*account),
+		nextID:   1,
+		now:      time.Now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a client configured for the server with Token, ApplicationID
+// and AccountHash as the default account, further options take precedence
+func (s *Server) Client(options ...striketracker.Option) (*striketracker.Client, error) {
+	return striketracker.NewClientWithOptions(append([]striketracker.Option{
+		striketracker.WithApplicationID(ApplicationID),
+		striketracker.WithAuthorizationHeaderToken(s.token),
+		striketracker.WithAccountHash(AccountHash),
+		striketracker.WithBaseURL(s.URL),
+	}, options...)...)
+}
+
+// FailNext answers the next request with err and the HTTP status,
+// repeated calls queue further failures
+func (s *Server) FailNext(status int, err *striketracker.APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{status: status, err: err})
+}
+
+// Requests returns every request received in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// Reset discards all resources, issued tokens, requests and queued failures
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.issued = make(map[string]bool)
+	s.refresh = make(map[string]bool)
+	s.accounts = make(map[string]*account)
+	s.requests = nil
+	s.failures = nil
+}
+
+// serve records the request, authenticates it and routes it
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := readRequestBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, striketracker.ErrValidationFailure, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		writeError(w, f.status, f.err, "")
+		return
+	}
+
+	if r.URL.Path == striketracker.AuthenticatePath {
+		s.authenticate(w, r, body)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, striketracker.ErrUnauthenticated, "")
+		return
+	}
+
+	prefix := endpoints.V1 + endpoints.ACCOUNTS + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+	if len(segments) < 2 || segments[0] == "" {
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+		return
+	}
+
+	a := s.account(segments[0])
+	switch segments[1] {
+	case "origins":
+		s.serveOrigins(w, r.Method, a, segments[2:], body)
+	case "certificates":
+		s.serveCertificates(w, r.Method, a, segments[2:], body)
+	case "hosts":
+		s.serveHosts(w, r.Method, a, segments[2:], body)
+	case "users":
+		s.serveTokens(w, r, a, segments[2:], body)
+	default:
+		writeError(w, http.StatusNotFound, striketracker.ErrEndpointNotFound, "")
+	}
+}
+
+// authorized reports whether the request carries an accepted bearer token
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token != "" && (token == s.token || s.issued[token])
+}
+
+// account returns the account, creating it on first use
+func (s *Server) account(hash string) *account {
+	a, ok := s.accounts[hash]
+	if !ok {
+		a = &account{
+			origins:      make(map[int]*models.Origin),
+			certificates: make(map[int]*models.Certificate),
+			hosts:        make(map[string]*models.Host),
+			tokens:       make(map[string]map[int]*models.AccessToken),
+		}
+		s.accounts[hash] = a
+	}
+	return a
+}
+
+// id returns the next resource ID
+func (s *Server) id() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// timestamp formats the current time as Striketracker does
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+// writeJSON writes v with the status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the Striketracker error envelope for err, appending detail to its message
+func writeError(w http.ResponseWriter, status int, err *striketracker.APIError, detail string) {
+	message := err.Message
+	if detail != "" {
+		message = fmt.Sprintf("%s: %s", message, detail)
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"error": message,
+		"code":  err.Code,
+	})
+}
@@ -0,0 +1,204 @@
+package striketrackertest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/openwurl/wurlwind/striketracker"
+	"github.com/openwurl/wurlwind/striketracker/endpoints"
+	"github.com/openwurl/wurlwind/striketracker/models"
+	"github.com/openwurl/wurlwind/striketracker/services/authentication"
+	"github.com/openwurl/wurlwind/striketracker/services/certificates"
+	"github.com/openwurl/wurlwind/striketracker/services/origin"
+)
+
+func TestOrigins(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	o := origin.New(c)
+	ctx := context.Background()
+
+	created, err := o.Create(ctx, striketracker.DefaultAccount, &models.Origin{Name: "fake", Hostname: "fake.example.com", Port: 80})
+	if err != nil {
+		t.Fatalf("Expected origin to be created but got: %v", err)
+	}
+	if created.ID == 0 || created.CreatedDate == "" || created.Type != "EXTERNAL" {
+		t.Fatalf("Expected server to assign an ID, dates and type but got %+v", created)
+	}
+
+	if _, err = o.Create(ctx, AccountHash, &models.Origin{Name: "copy", Hostname: "fake.example.com", Port: 80}); !errors.Is(err, striketracker.ErrDuplicateOrigin) {
+		t.Fatalf("Expected ErrDuplicateOrigin but got: %v", err)
+	}
+
+	var apiErr *striketracker.APIError
+	if _, err = c.Call(ctx, striketracker.POST, o.Endpoint.Format(AccountHash), nil, &struct{}{}, nil); !errors.As(err, &apiErr) || !errors.Is(err, striketracker.ErrValidationFailure) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected a validation failure from the server but got: %v", err)
+	}
+
+	if _, err = o.Get(ctx, AccountHash, created.ID+100); !errors.Is(err, striketracker.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound for a missing origin but got: %v", err)
+	}
+
+	created.Path = "/updated"
+	if _, err = o.Update(ctx, AccountHash, created); err != nil {
+		t.Fatalf("Expected origin to be updated but got: %v", err)
+	}
+	if origins := s.Origins(AccountHash); len(origins) != 1 || origins[0].Path != "/updated" {
+		t.Fatalf("Expected server state to hold the updated origin but got %+v", origins)
+	}
+	if len(s.Origins("otheraccount")) != 0 {
+		t.Fatalf("Expected accounts to be isolated")
+	}
+
+	if err = o.Delete(ctx, AccountHash, created.ID); err != nil {
+		t.Fatalf("Expected origin to be deleted but got: %v", err)
+	}
+	list, err := o.List(ctx, AccountHash)
+	if err != nil || len(list.List) != 0 {
+		t.Fatalf("Expected no origins after delete but got %v, %v", list, err)
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	certs := certificates.New(c)
+	ctx := context.Background()
+
+	cert, key, err := GenerateCertificate("fake.example.com")
+	if err != nil {
+		t.Fatalf("Expected a generated certificate but got: %v", err)
+	}
+
+	if _, err = certs.Upload(ctx, AccountHash, &models.Certificate{Certificate: "not a certificate", Key: key}); !errors.Is(err, striketracker.ErrValidationFailure) {
+		t.Fatalf("Expected an unparseable certificate to fail validation but got: %v", err)
+	}
+
+	uploaded, err := certs.Upload(ctx, AccountHash, &models.Certificate{Certificate: cert, Key: key})
+	if err != nil {
+		t.Fatalf("Expected certificate to be uploaded but got: %v", err)
+	}
+	if uploaded.ID == 0 || uploaded.CommonName != "fake.example.com" || uploaded.Fingerprint == "" {
+		t.Fatalf("Expected server to derive certificate details but got %+v", uploaded)
+	}
+
+	hosts, err := certs.Hosts(ctx, AccountHash, uploaded.ID)
+	if err != nil || hosts.CertificateName != "fake.example.com" {
+		t.Fatalf("Expected certificate hosts but got %+v, %v", hosts, err)
+	}
+
+	if err = certs.Delete(ctx, AccountHash, uploaded.ID); err != nil {
+		t.Fatalf("Expected certificate to be deleted but got: %v", err)
+	}
+	if _, err = certs.Get(ctx, AccountHash, uploaded.ID); !errors.Is(err, striketracker.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound after delete but got: %v", err)
+	}
+}
+
+func TestHosts(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	ctx := context.Background()
+	e := c.NewEndpoint(endpoints.Hosts, "/hosts")
+
+	host := &models.Host{Name: "fake host"}
+	if _, err = c.Call(ctx, striketracker.POST, e.Format(AccountHash), nil, host, host); err != nil {
+		t.Fatalf("Expected host to be created but got: %v", err)
+	}
+	if host.HashCode == "" || host.Type != "HOST" {
+		t.Fatalf("Expected server to assign a hash code and type but got %+v", host)
+	}
+
+	seeded := s.AddHost(AccountHash, models.Host{Name: "seeded"})
+	list := &models.HostList{}
+	if _, err = c.Call(ctx, striketracker.GET, e.Format(AccountHash), nil, nil, list); err != nil || len(list.List) != 2 {
+		t.Fatalf("Expected created and seeded hosts but got %+v, %v", list, err)
+	}
+
+	if _, err = c.Call(ctx, striketracker.DELETE, e.Segments(AccountHash, seeded.HashCode), nil, nil, nil); err != nil {
+		t.Fatalf("Expected host to be deleted but got: %v", err)
+	}
+	if hosts := s.Hosts(AccountHash); len(hosts) != 1 || hosts[0].HashCode != host.HashCode {
+		t.Fatalf("Expected only the created host to remain but got %+v", hosts)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client(striketracker.WithPasswordCredentials(Username, Password))
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	ctx := context.Background()
+
+	auth, err := authentication.New(c).Create(ctx, AccountHash, "42", Password, "fake application")
+	if err != nil {
+		t.Fatalf("Expected API token to be created but got: %v", err)
+	}
+	if auth.Token == "" || auth.Application != "fake application" {
+		t.Fatalf("Expected an API token for the application but got %+v", auth)
+	}
+	if tokens := s.Tokens(AccountHash, "42"); len(tokens) != 1 || tokens[0].Token != auth.Token {
+		t.Fatalf("Expected server state to hold the token but got %+v", tokens)
+	}
+
+	// Logging in happened before the create
+	requests := s.Requests()
+	if len(requests) != 2 || requests[0].Path != striketracker.AuthenticatePath {
+		t.Fatalf("Expected a login followed by the create but got %+v", requests)
+	}
+
+	// The new API token is accepted as a bearer token
+	apiClient, err := s.Client(striketracker.WithAuthorizationHeaderToken(auth.Token))
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	if _, err = origin.New(apiClient).List(ctx, AccountHash); err != nil {
+		t.Fatalf("Expected the API token to authenticate but got: %v", err)
+	}
+
+	badClient, err := s.Client(striketracker.WithAuthorizationHeaderToken("wrong"))
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	if _, err = origin.New(badClient).List(ctx, AccountHash); !errors.Is(err, striketracker.ErrUnauthenticated) {
+		t.Fatalf("Expected ErrUnauthenticated for an unknown token but got: %v", err)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c, err := s.Client()
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	s.FailNext(http.StatusServiceUnavailable, striketracker.ErrDatabaseDown)
+	if _, err = origin.New(c).List(context.Background(), AccountHash); !errors.Is(err, striketracker.ErrDatabaseDown) {
+		t.Fatalf("Expected the scripted failure but got: %v", err)
+	}
+	if _, err = origin.New(c).List(context.Background(), AccountHash); err != nil {
+		t.Fatalf("Expected only the next request to fail but got: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package striketrackertest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/openwurl/wurlwind/striketracker/models"
+)
+
+// Origins returns a copy of the account's origins ordered by ID
+func (s *Server) Origins(accountHash string) []models.Origin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account(accountHash).originList()
+}
+
+// Certificates returns a copy of the account's certificates ordered by ID
+func (s *Server) Certificates(accountHash string) []models.Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account(accountHash).certificateList()
+}
+
+// Hosts returns a copy of the account's hosts ordered by hash code
+func (s *Server) Hosts(accountHash string) []models.Host {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account(accountHash).hostList()
+}
+
+// Tokens returns a copy of the user's API tokens ordered by ID
+func (s *Server) Tokens(accountHash string, userID string) []models.AccessToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account(accountHash).tokenList(userID)
+}
+
+// AddOrigin seeds an origin without validation, assigning its ID and dates
+func (s *Server) AddOrigin(accountHash string, origin models.Origin) models.Origin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	origin.ID = s.id()
+	if origin.CreatedDate == "" {
+		origin.CreatedDate = s.timestamp()
+		origin.UpdatedDate = origin.CreatedDate
+	}
+	s.account(accountHash).origins[origin.ID] = &origin
+	return origin
+}
+
+// AddCertificate seeds a certificate without validation, assigning its ID and dates
+func (s *Server) AddCertificate(accountHash string, certificate models.Certificate) models.Certificate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	certificate.ID = s.id()
+	if certificate.CreatedDate == "" {
+		certificate.CreatedDate = s.timestamp()
+		certificate.UpdatedDate = certificate.CreatedDate
+	}
+	s.account(accountHash).certificates[certificate.ID] = &certificate
+	return certificate
+}
+
+// AddHost seeds a host without validation, assigning its hash code and dates
+func (s *Server) AddHost(accountHash string, host models.Host) models.Host {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host.HashCode = fmt.Sprintf("%010x", s.id())
+	if host.CreatedDate == "" {
+		host.CreatedDate = s.timestamp()
+		host.UpdatedDate = host.CreatedDate
+	}
+	s.account(accountHash).hosts[host.HashCode] = &host
+	return host
+}
+
+// GenerateCertificate returns a PEM encoded self-signed certificate and
+// key for commonName, valid for a year, which the server accepts
+func GenerateCertificate(commonName string) (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		Issuer:       pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM), nil
+}
+
+// originList copies the origins ordered by ID
+func (a *account) originList() []models.Origin {
+	ids := make([]int, 0, len(a.origins))
+	for id := range a.origins {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]models.Origin, len(ids))
+	for i, id := range ids {
+		list[i] = *a.origins[id]
+	}
+	return list
+}
+
+// certificateList copies the certificates ordered by ID
+func (a *account) certificateList() []models.Certificate {
+	ids := make([]int, 0, len(a.certificates))
+	for id := range a.certificates {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]models.Certificate, len(ids))
+	for i, id := range ids {
+		list[i] = *a.certificates[id]
+	}
+	return list
+}
+
+// hostList copies the hosts ordered by hash code
+func (a *account) hostList() []models.Host {
+	hashes := make([]string, 0, len(a.hosts))
+	for hash := range a.hosts {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	list := make([]models.Host, len(hashes))
+	for i, hash := range hashes {
+		list[i] = *a.hosts[hash]
+	}
+	return list
+}
+
+// tokenList copies the user's tokens ordered by ID
+func (a *account) tokenList(userID string) []models.AccessToken {
+	tokens := a.tokens[userID]
+	ids := make([]int, 0, len(tokens))
+	for id := range tokens {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	list := make([]models.AccessToken, len(ids))
+	for i, id := range ids {
+		list[i] = *tokens[id]
+	}
+	return list
+}
@@ -0,0 +1,148 @@
+package striketracker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Connection pooling defaults for the dedicated transport
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// DefaultRequestTimeout is used when no timeout is configured
+const DefaultRequestTimeout = 10 * time.Second
+
+// NewTransport returns a transport dedicated to a single client
+//
+// Striketracker is a single host, so the per-host idle pool is raised
+// well above the net/http default of 2 to allow reuse under concurrency
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          DefaultMaxIdleConns,
+		MaxIdleConnsPerHost:   DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:       DefaultIdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// newHTTPClient builds the http.Client owned by a striketracker Client
+//
+// An injected client is copied so the caller's value is never mutated,
+// and http.DefaultClient is never used
+func newHTTPClient(config *Configuration) (*http.Client, error) {
+	var hc http.Client
+	if config.HTTPClient != nil {
+		hc = *config.HTTPClient
+	}
+
+	if config.Transport != nil {
+		hc.Transport = config.Transport
+	}
+	if hc.Transport == nil {
+		transport := NewTransport()
+		if err := configureTransport(transport, config); err != nil {
+			return nil, err
+		}
+		hc.Transport = transport
+	} else if config.usesTransportOptions() {
+		return nil, fmt.Errorf("ProxyURL, root CAs and client certificates only apply to the dedicated transport, configure them on the supplied transport instead")
+	}
+
+	if config.Timeout != 0 {
+		hc.Timeout = time.Second * time.Duration(config.Timeout)
+	} else if hc.Timeout == 0 {
+		hc.Timeout = DefaultRequestTimeout
+	}
+
+	return &hc, nil
+}
+
+// usesTransportOptions reports whether any proxy or TLS options are set
+func (c *Configuration) usesTransportOptions() bool {
+	return c.ProxyURL != "" || c.usesTLSOptions()
+}
+
+// usesTLSOptions reports whether any root CA or client certificate options are set
+func (c *Configuration) usesTLSOptions() bool {
+	return len(c.RootCAFiles) > 0 || len(c.RootCAs) > 0 ||
+		c.ClientCertFile != "" || c.ClientKeyFile != "" || len(c.ClientCertificates) > 0
+}
+
+// configureTransport applies the proxy and TLS options to the dedicated transport
+func configureTransport(t *http.Transport, config *Configuration) error {
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid ProxyURL: %v", err)
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if !config.usesTLSOptions() {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(config.RootCAFiles) > 0 || len(config.RootCAs) > 0 {
+		pool, err := rootCAPool(config)
+		if err != nil {
+			return err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	tlsConfig.Certificates = append(tlsConfig.Certificates, config.ClientCertificates...)
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return fmt.Errorf("loading client certificate: %v", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	t.TLSClientConfig = tlsConfig
+	return nil
+}
+
+// rootCAPool adds the extra root CA bundles to the system roots
+func rootCAPool(config *Configuration) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	for _, path := range config.RootCAFiles {
+		bundle, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading root CA bundle %s: %v", path, err)
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in root CA bundle %s", path)
+		}
+	}
+
+	for i, bundle := range config.RootCAs {
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in root CA bundle %d", i)
+		}
+	}
+
+	return pool, nil
+}
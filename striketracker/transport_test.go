@@ -0,0 +1,220 @@
+package striketracker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// serverCAPEM returns the PEM encoded certificate of a TLS test server
+func serverCAPEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+// newClientCertificate returns a self signed client certificate and its PEM encoding
+func newClientCertificate(t *testing.T) (tls.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected client key but got: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "wurlwind test client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Expected client certificate but got: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Expected encoded client key but got: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("Expected client key pair but got: %v", err)
+	}
+	return cert, certPEM, keyPEM
+}
+
+func TestRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"secure"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+	if _, err = c.Call(context.Background(), GET, server.URL, nil, nil, nil); err == nil {
+		t.Fatalf("Expected an untrusted server certificate to be rejected")
+	}
+
+	dir, err := ioutil.TempDir("", "striketracker")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	bundle := filepath.Join(dir, "ca.pem")
+	if err = ioutil.WriteFile(bundle, serverCAPEM(server), 0600); err != nil {
+		t.Fatalf("Expected to write CA bundle but got: %v", err)
+	}
+
+	for name, option := range map[string]Option{
+		"file":   WithRootCAFiles(bundle),
+		"inline": WithRootCAs(serverCAPEM(server)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClientWithOptions(
+				WithApplicationID(TestID),
+				WithAuthorizationHeaderToken(TestToken),
+				option,
+			)
+			if err != nil {
+				t.Fatalf("Expected client to configure successfully but got: %v", err)
+			}
+
+			out := &validatedModel{}
+			if _, err = c.Call(context.Background(), GET, server.URL, nil, nil, out); err != nil {
+				t.Fatalf("Expected the extra root CA to be trusted but got: %v", err)
+			}
+			if out.Name != "secure" {
+				t.Fatalf("Expected decoded output but got %v", out)
+			}
+		})
+	}
+
+	if _, err = NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithRootCAs([]byte("not a certificate")),
+	); err == nil {
+		t.Fatalf("Expected an error for a bundle without certificates")
+	}
+}
+
+func TestClientCertificates(t *testing.T) {
+	cert, certPEM, keyPEM := newClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"` + r.TLS.PeerCertificates[0].Subject.CommonName + `"}`))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "striketracker")
+	if err != nil {
+		t.Fatalf("Expected temporary directory but got: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	ioutil.WriteFile(certFile, certPEM, 0600)
+	ioutil.WriteFile(keyFile, keyPEM, 0600)
+
+	var testSuite = []struct {
+		name    string
+		options []Option
+		fails   bool
+	}{
+		{name: "no certificate", fails: true},
+		{name: "files", options: []Option{WithClientCertificateFiles(certFile, keyFile)}},
+		{name: "inline", options: []Option{WithClientCertificate(cert)}},
+	}
+
+	for _, tt := range testSuite {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]Option{
+				WithApplicationID(TestID),
+				WithAuthorizationHeaderToken(TestToken),
+				WithRootCAs(serverCAPEM(server)),
+			}, tt.options...)
+			c, err := NewClientWithOptions(options...)
+			if err != nil {
+				t.Fatalf("Expected client to configure successfully but got: %v", err)
+			}
+
+			out := &validatedModel{}
+			_, err = c.Call(context.Background(), GET, server.URL, nil, nil, out)
+			if tt.fails {
+				if err == nil {
+					t.Fatalf("Expected the handshake to fail without a client certificate")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected mutual TLS to succeed but got: %v", err)
+			}
+			if out.Name != "wurlwind test client" {
+				t.Fatalf("Expected server to see the client certificate but got %v", out)
+			}
+		})
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Write([]byte(`{"name":"proxied"}`))
+	}))
+	defer proxy.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithProxyURL(proxy.URL),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	target := "http://striketracker.invalid/api/v1/accounts"
+	if _, err = c.Call(context.Background(), GET, target, nil, nil, nil); err != nil {
+		t.Fatalf("Expected request through the proxy to succeed but got: %v", err)
+	}
+	if proxied != target {
+		t.Fatalf("Expected proxy to receive %s but got %s", target, proxied)
+	}
+
+	if _, err = NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithProxyURL(proxy.URL),
+		WithTransport(http.DefaultTransport),
+	); err == nil {
+		t.Fatalf("Expected an error when proxy options cannot be applied to a supplied transport")
+	}
+}
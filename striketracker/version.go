@@ -0,0 +1,27 @@
+package striketracker
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Version is the wurlwind library version reported in the User-Agent
+const Version = "0.2.0"
+
+// UserAgent builds the User-Agent sent with every request, identifying the
+// library version, Go runtime, platform and application
+//
+//  wurlwind/0.2.0 (go1.13.8; linux/amd64) DescriptiveApplicationName suffix
+func UserAgent(applicationID string, suffix string) string {
+	parts := []string{
+		fmt.Sprintf("wurlwind/%s (%s; %s/%s)", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH),
+	}
+	if applicationID != "" {
+		parts = append(parts, applicationID)
+	}
+	if suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,45 @@
+package striketracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithOptions(
+		WithApplicationID(TestID),
+		WithAuthorizationHeaderToken(TestToken),
+		WithUserAgentSuffix("deploy-bot/1.2.0"),
+	)
+	if err != nil {
+		t.Fatalf("Expected client to configure successfully but got: %v", err)
+	}
+
+	if _, err = c.Call(context.Background(), GET, server.URL, nil, nil, nil); err != nil {
+		t.Fatalf("Expected call to succeed but got: %v", err)
+	}
+
+	for _, part := range []string{"wurlwind/" + Version, runtime.Version(), TestID, "deploy-bot/1.2.0"} {
+		if !strings.Contains(userAgent, part) {
+			t.Fatalf("Expected User-Agent to contain %s but got %s", part, userAgent)
+		}
+	}
+
+	if !strings.HasSuffix(userAgent, TestID+" deploy-bot/1.2.0") {
+		t.Fatalf("Expected the suffix to follow the application ID but got %s", userAgent)
+	}
+
+	if userAgent != c.UserAgent() {
+		t.Fatalf("Expected client to report the User-Agent it sends, %s != %s", c.UserAgent(), userAgent)
+	}
+}